@@ -3,11 +3,13 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 	"strings"
+	"text/tabwriter"
 
 	"anki-japanese-cli/internal/anki"
 	"anki-japanese-cli/internal/config"
+	"anki-japanese-cli/internal/furigana"
 	"anki-japanese-cli/internal/models"
 
 	"github.com/spf13/cobra"
@@ -19,23 +21,65 @@ var addCmd = &cobra.Command{
 	Short: "新增日文卡片到 Anki",
 	Long: `新增日文卡片到 Anki 牌組。
 
-這個指令支援四種卡片類型：
+這個指令支援以下卡片類型 (以及設定目錄中的自訂類型)：
 - verb: 動詞卡片
 - adjective: 形容詞卡片
 - normal: 一般單字卡片
 - grammar: 文法卡片
+- kanji: 漢字卡片
+- cloze: 克漏字卡片 (克漏字欄位使用 {{c1::答案}} 語法)
 
 支援多種新增方式：
 - 從 JSON 字串新增
 - 從 JSON 檔案讀取
 - 批次處理模式
+- 從 CSV 或 TSV 檔案讀取 (依副檔名判斷，每一列為一張卡片)
+- 從 YAML (.yaml/.yml) 或 NDJSON (.ndjson/.jsonl) 檔案讀取
+- 從標準輸入讀取 (--file -，預設為 NDJSON，可用 --format 指定格式)
+
+檔案會逐筆讀取與驗證，大型的 JSON 陣列或 NDJSON 檔案不會一次載入記憶體。
+YAML 檔案可包含多個文件 (以 --- 分隔)，每個文件為一張卡片或卡片的清單。
+
+新增前會以核心單字 (文法卡片為文法要點) 檢查重複的卡片，
+並依 --on-duplicate 處理:
+- fail: 列出重複的卡片並中止，不新增任何卡片 (預設)
+- skip: 略過重複的卡片，只新增其餘卡片
+- update: 以新資料更新既有筆記
+- allow: 不檢查，允許新增重複的卡片
+
+新增動詞卡片時，常用變化為空會依核心單字與詞性分類 (五段/一段/サ變/カ變)
+自動產生 ます形、て形、た形、ない形、可能形、受身形、使役形、意向形、命令形與條件形；
+形容詞卡片的主要變化同樣會依い形容詞或な形容詞自動產生否定形、過去形、過去否定形、
+て形、副詞形與條件形。已填寫的變化會與產生的結果比對，不一致時發出警告
+(--conjugations=fix 直接取代，--conjugations=off 不自動產生)。
+
+使用 --furigana 時，會以振り仮名字典為情境例句與核心單字中的漢字加上
+Anki 的讀音標註 (寝る → 寝[ね]る)，卡片背面以 <ruby> 顯示讀音。
+內建字典收錄常用單字，可用 --furigana-dict 或設定檔的 furigana_dictionary
+指定額外的字典檔案 (每行「單字<Tab>讀音」)。
+
+CSV/TSV 檔案的第一列為標題，標題即欄位名稱 (核心單字、發音…)；
+其他標題可用 --map 對應到欄位，例如 --map "word=核心單字,reading=發音"。
+
+發音可以用羅馬拼音填寫 (taberu → たべる)，半形片假名與全形英數字也會先統一；
+正規化後發音包含漢字或英文字母的卡片會驗證失敗。
+
+新增 cloze 卡片時可使用 --from=verb 或 --from=normal，直接讀取動詞或
+一般單字卡片的資料，將情境例句中的核心單字挖空產生克漏字 (以核心意義作為提示)。
 
 範例:
   anki-japanese-cli add verb --deckName="日文動詞" --json='{"核心單字":"飲む", "詞性分類":"五段動詞", "核心意義":"喝"}'
   anki-japanese-cli add normal --deckName="日文單字" --file=words.json
-  anki-japanese-cli add grammar --deckName="日文文法" --batch --file=grammar_batch.json`,
+  anki-japanese-cli add grammar --deckName="日文文法" --batch --file=grammar_batch.json
+  anki-japanese-cli add verb --deckName="日文動詞" --batch --file=verbs.json --on-duplicate=skip
+  anki-japanese-cli add cloze --deckName="日文克漏字" --batch --file=verbs.json --from=verb
+  anki-japanese-cli add verb --deckName="日文動詞" --batch --file=verbs.json --furigana
+  anki-japanese-cli add normal --deckName="日文單字" --file=words.csv --map "word=核心單字,reading=發音,meaning=核心意義"
+  anki-japanese-cli add verb --deckName="日文動詞" --batch --file=verbs.yaml
+  ./generate_words.py | anki-japanese-cli add normal --deckName="日文單字" --batch --file=-`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 		cardType := strings.ToLower(args[0])
 
 		// 驗證卡片類型
@@ -50,6 +94,15 @@ var addCmd = &cobra.Command{
 		jsonStr, _ := cmd.Flags().GetString("json")
 		filePath, _ := cmd.Flags().GetString("file")
 		batchMode, _ := cmd.Flags().GetBool("batch")
+		onDuplicate, _ := cmd.Flags().GetString("on-duplicate")
+		failedOutput, _ := cmd.Flags().GetString("failed-output")
+		skipInvalid, _ := cmd.Flags().GetBool("skip-invalid")
+		clozeFrom, _ := cmd.Flags().GetString("from")
+		conjugationsMode, _ := cmd.Flags().GetString("conjugations")
+		furiganaEnabled, _ := cmd.Flags().GetBool("furigana")
+		furiganaDict, _ := cmd.Flags().GetString("furigana-dict")
+		fieldMapSpec, _ := cmd.Flags().GetString("map")
+		inputFormat, _ := cmd.Flags().GetString("format")
 
 		// 檢查必要參數
 		if deckName == "" {
@@ -57,6 +110,50 @@ var addCmd = &cobra.Command{
 			cmd.Help()
 			return fmt.Errorf("請指定目標牌組名稱")
 		}
+		if err := validateOnDuplicate(onDuplicate); err != nil {
+			cmd.PrintErrf("錯誤: %v\n", err)
+			return err
+		}
+		if err := validateConjugationsMode(conjugationsMode); err != nil {
+			cmd.PrintErrf("錯誤: %v\n", err)
+			return err
+		}
+		var dict *furigana.Dictionary
+		if furiganaEnabled {
+			loaded, err := loadFuriganaDictionary(furiganaDict)
+			if err != nil {
+				cmd.PrintErrf("錯誤: %v\n", err)
+				return err
+			}
+			dict = loaded
+		}
+		// 檢查重複卡片時以振り仮名字典比對標註過的核心單字 (未使用 --furigana 時也比對)
+		keyDict := dict
+		if keyDict == nil {
+			keyDict = keyFuriganaDictionary()
+		}
+		if inputFormat == "" {
+			inputFormat = detectInputFormat(filePath)
+		} else if err := validateInputFormat(inputFormat); err != nil {
+			cmd.PrintErrf("錯誤: %v\n", err)
+			return err
+		}
+		var fieldMap map[string]string
+		if fieldMapSpec != "" {
+			if filePath == "" || (inputFormat != inputFormatCSV && inputFormat != inputFormatTSV) {
+				cmd.PrintErrf("錯誤: --map 只能用於 CSV 或 TSV 檔案\n")
+				return fmt.Errorf("--map 只能用於 CSV 或 TSV 檔案")
+			}
+			var err error
+			if fieldMap, err = parseFieldMap(fieldMapSpec); err != nil {
+				cmd.PrintErrf("錯誤: %v\n", err)
+				return err
+			}
+		}
+		if clozeFrom != "" && cardType != "cloze" {
+			cmd.PrintErrf("錯誤: --from 只能用於 cloze 卡片\n")
+			return fmt.Errorf("--from 只能用於 cloze 卡片")
+		}
 
 		// 載入設定
 		cfg, err := config.LoadConfig()
@@ -68,74 +165,88 @@ var addCmd = &cobra.Command{
 		// 建立 Anki 客戶端
 		client := anki.NewClient(&cfg.Anki)
 
-		// 檢查 Anki Connect 連線狀態
+		// 檢查模型名稱
+		modelName := cardModelName(cardType)
+
+		// 以單一 multi 請求檢查連線並取得模型列表；牌組在確認模型存在且有卡片要新增時才建立
 		cmd.Println("檢查 Anki Connect 連線狀態...")
-		if err := client.Ping(); err != nil {
+		batch := client.NewBatch()
+		versionIdx := batch.Add("version", nil)
+		modelsIdx := batch.Add("modelNames", nil)
+		modelFieldsIdx := batch.QueueModelFieldNames(modelName)
+
+		results, err := batch.ExecuteContext(ctx)
+		if err == nil {
+			err = results[versionIdx].Err
+		}
+		if err != nil {
 			fmt.Printf("錯誤: 無法連線到 Anki: %v\n", err)
 			fmt.Println("請確認 Anki 已啟動且已安裝 AnkiConnect 插件。")
 			return fmt.Errorf("無法連線到 Anki: %w", err)
 		}
 		fmt.Println("✓ 成功連線到 Anki")
 
-		// 確保牌組存在
-		fmt.Printf("確保牌組 '%s' 存在...\n", deckName)
-		if err := client.EnsureDeckExists(deckName); err != nil {
-			fmt.Printf("錯誤: 無法確保牌組存在: %v\n", err)
-			return fmt.Errorf("無法確保牌組存在: %w", err)
-		}
-		fmt.Printf("✓ 牌組 '%s' 已就緒\n", deckName)
-
 		// 檢查模型是否存在
-		modelName := ""
-		switch cardType {
-		case "verb":
-			modelName = "Japanese Verb"
-		case "adjective":
-			modelName = "Japanese Adjective"
-		case "normal":
-			modelName = "Japanese Normal Word"
-		case "grammar":
-			modelName = "Japanese Grammar"
-		}
-
-		exists, err := client.ModelExists(modelName)
+		modelNames, err := results[modelsIdx].Strings()
 		if err != nil {
 			fmt.Printf("錯誤: 檢查模型時發生錯誤: %v\n", err)
 			return fmt.Errorf("檢查模型時發生錯誤: %w", err)
 		}
 
+		exists := false
+		for _, name := range modelNames {
+			if name == modelName {
+				exists = true
+				break
+			}
+		}
+
 		if !exists {
 			fmt.Printf("錯誤: 模型 '%s' 不存在。請先執行 'init %s' 指令建立模型。\n", modelName, cardType)
 			return fmt.Errorf("模型 '%s' 不存在", modelName)
 		}
 
-		// 處理卡片資料
-		var cardData []map[string]interface{}
+		// 取得模型欄位，用來檢查卡片資料的欄位
+		modelFields, err := results[modelFieldsIdx].Strings()
+		if err != nil {
+			fmt.Printf("錯誤: 無法取得模型欄位: %v\n", err)
+			return fmt.Errorf("無法取得模型欄位: %w", err)
+		}
 
-		// 從檔案讀取
+		// 卡片資料來源；檔案逐筆讀取，不會一次載入所有卡片資料。
+		// 驗證與新增各讀取一次，標準輸入先暫存到檔案
+		var open func() (cardSource, error)
 		if filePath != "" {
-			fmt.Printf("從檔案 '%s' 讀取卡片資料...\n", filePath)
-			fileContent, err := os.ReadFile(filePath)
-			if err != nil {
-				fmt.Printf("錯誤: 無法讀取檔案: %v\n", err)
-				return fmt.Errorf("無法讀取檔案: %w", err)
-			}
-
-			// 批次模式
-			if batchMode {
-				if err := json.Unmarshal(fileContent, &cardData); err != nil {
-					fmt.Printf("錯誤: JSON 解析失敗: %v\n", err)
-					return fmt.Errorf("JSON 解析失敗: %w", err)
+			path := filePath
+			if filePath == stdinPath {
+				fmt.Printf("從標準輸入讀取 %s 卡片資料...\n", strings.ToUpper(inputFormat))
+				spooled, cleanup, err := spoolStdin(cmd.InOrStdin())
+				if err != nil {
+					fmt.Printf("錯誤: %v\n", err)
+					return err
 				}
+				defer cleanup()
+				path = spooled
 			} else {
-				// 單一卡片模式
-				var singleCard map[string]interface{}
-				if err := json.Unmarshal(fileContent, &singleCard); err != nil {
-					fmt.Printf("錯誤: JSON 解析失敗: %v\n", err)
-					return fmt.Errorf("JSON 解析失敗: %w", err)
+				fmt.Printf("從檔案 '%s' 讀取卡片資料...\n", filePath)
+			}
+			var closeSource func() error
+			open = func() (cardSource, error) {
+				if closeSource != nil {
+					closeSource()
+				}
+				source, closeFile, err := openCardSource(path, inputFormat, batchMode, fieldMap)
+				if err != nil {
+					return nil, err
 				}
-				cardData = append(cardData, singleCard)
+				closeSource = closeFile
+				return source, nil
 			}
+			defer func() {
+				if closeSource != nil {
+					closeSource()
+				}
+			}()
 		} else if jsonStr != "" {
 			// 從 JSON 字串讀取
 			var singleCard map[string]interface{}
@@ -143,93 +254,180 @@ var addCmd = &cobra.Command{
 				fmt.Printf("錯誤: JSON 解析失敗: %v\n", err)
 				return fmt.Errorf("JSON 解析失敗: %w", err)
 			}
-			cardData = append(cardData, singleCard)
+			open = func() (cardSource, error) {
+				return &sliceSource{cards: []map[string]interface{}{singleCard}}, nil
+			}
 		} else {
 			fmt.Println("錯誤: 請提供卡片資料 (--json 或 --file)")
 			cmd.Help()
 			return fmt.Errorf("請提供卡片資料")
 		}
 
-		// 驗證卡片資料
-		if len(cardData) == 0 {
-			fmt.Println("錯誤: 沒有有效的卡片資料")
-			return fmt.Errorf("沒有有效的卡片資料")
+		im := &cardImporter{
+			ctx:              ctx,
+			client:           client,
+			out:              cmd.OutOrStdout(),
+			factory:          factory,
+			cardType:         cardType,
+			clozeFrom:        clozeFrom,
+			deckName:         deckName,
+			modelName:        modelName,
+			modelFields:      modelFields,
+			conjugationsMode: conjugationsMode,
+			dict:             dict,
+			keyDict:          keyDict,
+			onDuplicate:      onDuplicate,
+			batchMode:        batchMode,
+			chunkSize:        addChunkSize,
 		}
-
-		// 驗證所有卡片資料
-		fmt.Printf("驗證 %d 張卡片資料...\n", len(cardData))
-
-		// 處理每張卡片
-		var notes []anki.NoteInfo
-		for i, data := range cardData {
-			// 驗證卡片資料
-			_, err := factory.CreateCard(cardType, data)
+		// 失敗卡片的輸出檔案在開始匯入前建立，檔案已存在時不會新增任何卡片
+		if failedOutput != "" {
+			failed, err := newFailedWriter(failedOutput)
 			if err != nil {
-				fmt.Printf("錯誤: 卡片 #%d 驗證失敗: %v\n", i+1, err)
-				return fmt.Errorf("卡片 #%d 驗證失敗: %w", i+1, err)
-			}
-
-			// 建立 Anki 筆記
-			note := anki.NoteInfo{
-				DeckName:  deckName,
-				ModelName: modelName,
-				Fields:    make(map[string]string),
-				Tags:      []string{"anki-japanese-cli", cardType},
+				fmt.Printf("錯誤: 無法建立失敗卡片的輸出檔案: %v\n", err)
+				return fmt.Errorf("無法建立失敗卡片的輸出檔案: %w", err)
 			}
+			defer failed.Close()
+			im.failed = failed
+		}
 
-			// 轉換欄位
-			for key, value := range data {
-				if strValue, ok := value.(string); ok {
-					note.Fields[key] = strValue
-				} else {
-					// 將非字串值轉換為字串
-					jsonValue, _ := json.Marshal(value)
-					note.Fields[key] = string(jsonValue)
-				}
-			}
+		// 驗證所有卡片資料 (--on-duplicate=fail 時同時檢查重複)，有錯誤時不新增任何卡片
+		fmt.Println("驗證卡片資料...")
+		source, err := open()
+		if err != nil {
+			fmt.Printf("錯誤: %v\n", err)
+			return err
+		}
+		if err := im.check(source); err != nil {
+			fmt.Printf("錯誤: %v\n", err)
+			return err
+		}
 
-			notes = append(notes, note)
+		if im.total == 0 {
+			fmt.Println("錯誤: 沒有有效的卡片資料")
+			return fmt.Errorf("沒有有效的卡片資料")
 		}
 
-		// 新增卡片到 Anki
-		if len(notes) == 1 {
-			// 單一卡片模式
-			fmt.Println("正在新增卡片到 Anki...")
-			noteID, err := client.AddNote(notes[0])
-			if err != nil {
-				fmt.Printf("錯誤: 無法新增卡片: %v\n", err)
-				return fmt.Errorf("無法新增卡片: %w", err)
+		// 回報驗證結果
+		if im.completed > 0 {
+			fmt.Printf("已自動產生 %d 張卡片的常用變化或主要變化\n", im.completed)
+		}
+		if im.annotated > 0 {
+			fmt.Printf("已為 %d 張卡片加上振り仮名\n", im.annotated)
+		}
+		if im.warnedCount > 0 {
+			printWarningReport(cmd.OutOrStdout(), im.warned, im.warnedCount)
+		}
+		if len(im.invalid) > 0 {
+			printValidationReport(cmd.OutOrStdout(), im.invalid, im.total)
+			if !skipInvalid {
+				fmt.Println("未新增任何卡片。修正上述錯誤，或使用 --skip-invalid 只匯入有效的卡片。")
+				return fmt.Errorf("%d 張卡片驗證失敗", len(im.invalid))
 			}
-			fmt.Printf("✓ 成功新增卡片 (ID: %d)\n", noteID)
+			fmt.Printf("略過 %d 張驗證失敗的卡片\n", len(im.invalid))
 		} else {
-			// 批次模式
-			fmt.Printf("正在批次新增 %d 張卡片到 Anki...\n", len(notes))
-			noteIDs, err := client.AddNotes(notes)
-			if err != nil {
-				fmt.Printf("錯誤: 無法批次新增卡片: %v\n", err)
-				return fmt.Errorf("無法批次新增卡片: %w", err)
+			fmt.Printf("✓ %d 張卡片驗證通過\n", im.total)
+		}
+
+		// --on-duplicate=fail 時有任何重複的卡片就中止
+		if onDuplicate == onDuplicateFail && len(im.invalid) < im.total {
+			if len(im.duplicates) > 0 {
+				fmt.Printf("發現 %d 張重複的卡片:\n", len(im.duplicates))
+				printDuplicates(cmd.OutOrStdout(), im.duplicates)
+				fmt.Println("未新增任何卡片。使用 --on-duplicate=skip 略過、update 更新既有筆記，或 allow 仍然新增。")
+				return fmt.Errorf("發現 %d 張重複的卡片", len(im.duplicates))
 			}
+			fmt.Println("✓ 沒有重複的卡片")
+		}
 
-			// 計算成功和失敗的數量
-			successCount := 0
-			for _, id := range noteIDs {
-				if id != 0 {
-					successCount++
-				}
+		// 分批新增卡片，每一批新增後輸出失敗的卡片並釋放
+		if source, err = open(); err != nil {
+			fmt.Printf("錯誤: %v\n", err)
+			return err
+		}
+		addErr := im.add(source)
+		if im.failed != nil {
+			if err := im.failed.Close(); err != nil && addErr == nil {
+				addErr = fmt.Errorf("無法寫入失敗的卡片: %w", err)
+			}
+			if im.failed.count > 0 {
+				fmt.Printf("已將 %d 張失敗的卡片寫入 '%s'\n", im.failed.count, failedOutput)
 			}
+		}
+		if addErr != nil {
+			fmt.Printf("錯誤: %v\n", addErr)
+			return addErr
+		}
 
-			fmt.Printf("✓ 成功新增 %d/%d 張卡片\n", successCount, len(notes))
+		switch {
+		case im.added == 0 && im.addFailed == 0:
+			if len(im.invalid) == im.total {
+				fmt.Println("沒有有效的卡片可新增")
+			} else {
+				fmt.Println("沒有需要新增的卡片")
+			}
+		case im.batchMode || im.total > 1:
+			fmt.Printf("✓ 成功新增 %d/%d 張卡片\n", im.added, im.added+im.addFailed)
 		}
 		return nil
 	},
 }
 
+// printAddResults 列出批次新增中每張卡片的結果，回傳失敗卡片在輸入資料中的位置
+func printAddResults(w io.Writer, cardType string, positions []int, results []anki.AddNoteResult) []int {
+	var failed []int
+	keyField := cardKeyField(cardType)
+	if len(results) > 0 {
+		keyField = modelKeyField(cardType, results[0].Note.Fields)
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "#\t%s\t結果\n", keyField)
+	for _, result := range results {
+		position := positions[result.Index]
+		key := result.Note.Fields[keyField]
+		if result.Err != nil {
+			fmt.Fprintf(writer, "%d\t%s\t✗ %s\n", position+1, key, anki.FormatError(result.Err))
+			failed = append(failed, position)
+			continue
+		}
+		fmt.Fprintf(writer, "%d\t%s\t✓ ID: %d\n", position+1, key, result.NoteID)
+	}
+	writer.Flush()
+
+	return failed
+}
+
+// noteFieldsFromData 將卡片資料轉換為 Anki 筆記欄位
+func noteFieldsFromData(data map[string]interface{}) map[string]string {
+	fields := make(map[string]string, len(data))
+	for key, value := range data {
+		if strValue, ok := value.(string); ok {
+			fields[key] = strValue
+		} else {
+			// 將非字串值轉換為字串
+			jsonValue, _ := json.Marshal(value)
+			fields[key] = string(jsonValue)
+		}
+	}
+	return fields
+}
+
 func init() {
 	rootCmd.AddCommand(addCmd)
 
 	// 定義 flags
 	addCmd.Flags().String("deckName", "", "目標牌組名稱")
 	addCmd.Flags().String("json", "", "JSON 格式的卡片資料")
-	addCmd.Flags().StringP("file", "f", "", "包含卡片資料的 JSON 檔案路徑")
+	addCmd.Flags().StringP("file", "f", "", "包含卡片資料的檔案路徑 (JSON、NDJSON、YAML、CSV 或 TSV，依副檔名判斷；- 為標準輸入)")
+	addCmd.Flags().String("format", "", "卡片資料檔案的格式 (json, ndjson, yaml, csv, tsv)，預設依副檔名判斷，標準輸入預設為 ndjson")
+	addCmd.Flags().String("map", "", "CSV/TSV 標題與卡片欄位的對應 (例如 \"word=核心單字,reading=發音\")")
 	addCmd.Flags().BoolP("batch", "b", false, "批次處理模式 (從檔案讀取多張卡片)")
+	addCmd.Flags().String("failed-output", "", "將新增失敗的卡片寫入此 JSON 檔案 (檔案不可已存在)")
+	addCmd.Flags().Bool("skip-invalid", false, "略過驗證失敗的卡片，只新增有效的卡片")
+	addCmd.Flags().String("on-duplicate", onDuplicateFail, "重複卡片的處理方式 (skip, update, fail, allow)")
+	addCmd.Flags().String("from", "", "由 verb 或 normal 卡片資料產生 cloze 卡片")
+	addCmd.Flags().Bool("furigana", false, "為情境例句與核心單字的漢字加上振り仮名標註")
+	addCmd.Flags().String("furigana-dict", "", "額外的振り仮名字典檔案 (每行「單字<Tab>讀音」，預設使用設定檔的 furigana_dictionary)")
+	addCmd.Flags().String("conjugations", conjugationsFill, "動詞常用變化與形容詞主要變化的自動產生方式 (fill: 空白時產生, fix: 也取代不一致的變化, off: 不產生)")
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"os"
 	"strings"
@@ -350,3 +351,87 @@ func TestAddCommandWithFileUnit(t *testing.T) {
 		})
 	}
 }
+
+// TestPrintAddResults tests the per-note report of a batch add
+func TestPrintAddResults(t *testing.T) {
+	results := []anki.AddNoteResult{
+		{Index: 0, Note: anki.NoteInfo{Fields: map[string]string{"核心單字": "食べる"}}, NoteID: 1496198395707},
+		{Index: 1, Note: anki.NoteInfo{Fields: map[string]string{"核心單字": "飲む"}}, Err: errors.New("cannot create note")},
+	}
+
+	var out bytes.Buffer
+	failed := printAddResults(&out, "verb", []int{2, 5}, results)
+
+	if len(failed) != 1 || failed[0] != 5 {
+		t.Errorf("printAddResults() failed = %v, expected [5]", failed)
+	}
+	report := out.String()
+	for _, want := range []string{"3", "食べる", "1496198395707", "6", "飲む", "cannot create note"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("printAddResults() output missing %q:\n%s", want, report)
+		}
+	}
+}
+
+// TestFailedWriter tests writing failed entries in several chunks to a new JSON file
+func TestFailedWriter(t *testing.T) {
+	path := t.TempDir() + "/failed.json"
+
+	// Nothing written: the file is removed again
+	writer, err := newFailedWriter(path)
+	if err != nil {
+		t.Fatalf("newFailedWriter() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Close() without entries left %s behind", path)
+	}
+
+	writer, err = newFailedWriter(path)
+	if err != nil {
+		t.Fatalf("newFailedWriter() error = %v", err)
+	}
+	for _, entry := range []map[string]interface{}{{"核心單字": "飲む"}, {"核心單字": "食べる"}} {
+		if err := writer.Write([]map[string]interface{}{entry}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read output: %v", err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, content)
+	}
+	if len(entries) != 2 || entries[0]["核心單字"] != "飲む" || entries[1]["核心單字"] != "食べる" {
+		t.Errorf("entries = %v, expected 飲む and 食べる", entries)
+	}
+	if writer.count != 2 {
+		t.Errorf("count = %d, expected 2", writer.count)
+	}
+}
+
+// TestFailedWriterExistingFile tests that an existing output file is rejected before anything is written
+func TestFailedWriterExistingFile(t *testing.T) {
+	path := t.TempDir() + "/failed.json"
+	if err := os.WriteFile(path, []byte("keep"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	if _, err := newFailedWriter(path); err == nil {
+		t.Error("newFailedWriter() expected error for existing file")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "keep" {
+		t.Errorf("existing file was changed: %q, %v", content, err)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"anki-japanese-cli/internal/models"
+)
+
+// 動詞常用變化與形容詞主要變化的自動產生方式 (--conjugations)
+const (
+	conjugationsFill = "fill" // 空白時自動產生，不一致時只發出警告
+	conjugationsFix  = "fix"  // 空白或不一致時都以產生的活用形取代
+	conjugationsOff  = "off"  // 不自動產生
+)
+
+// validateConjugationsMode 檢查 --conjugations 的值
+func validateConjugationsMode(mode string) error {
+	switch mode {
+	case conjugationsFill, conjugationsFix, conjugationsOff:
+		return nil
+	}
+	return fmt.Errorf("不支援的變化處理方式: %s (可用: fill, fix, off)", mode)
+}
+
+// completeConjugations 依 --conjugations 補上或修正動詞卡片的常用變化與形容詞卡片的主要變化。
+// 有修改時回傳更新後的卡片資料 (不修改原本的 map)；
+// 無法產生時 (例如無法辨識詞性分類) 回傳警告
+func completeConjugations(card models.CardType, data map[string]interface{}, mode string) (map[string]interface{}, bool, error) {
+	if mode == conjugationsOff {
+		return data, false, nil
+	}
+
+	var field, value string
+	var changed bool
+	var err error
+	switch c := card.(type) {
+	case *models.VerbCard:
+		changed, err = c.FillConjugations(mode == conjugationsFix)
+		field, value = "常用變化", c.Conjugations
+	case *models.AdjectiveCard:
+		changed, err = c.FillInflections(mode == conjugationsFix)
+		field, value = "主要變化", c.MainChanges
+	default:
+		return data, false, nil
+	}
+
+	if err != nil {
+		if value != "" {
+			return data, false, nil
+		}
+		return data, false, models.NewValidationWarning(field, fmt.Sprintf("無法自動產生: %v", err))
+	}
+	if !changed {
+		return data, false, nil
+	}
+	return withField(data, field, value), true, nil
+}
+
+// withField 回傳設定了指定欄位的卡片資料副本
+func withField(data map[string]interface{}, field string, value interface{}) map[string]interface{} {
+	updated := make(map[string]interface{}, len(data)+1)
+	for key, v := range data {
+		updated[key] = v
+	}
+	updated[field] = value
+	return updated
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"anki-japanese-cli/internal/models"
+)
+
+// TestCompleteConjugations tests filling and fixing 常用變化 according to --conjugations
+func TestCompleteConjugations(t *testing.T) {
+	factory := models.NewCardFactory()
+	newData := func(conjugations string) map[string]interface{} {
+		return map[string]interface{}{
+			"核心單字": "飲む", "詞性分類": "五段動詞", "核心意義": "喝", "發音": "のむ",
+			"常用變化": conjugations, "情境例句": "水を飲む", "例句翻譯": "喝水",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]interface{}
+		mode     string
+		changed  bool
+		expected string // prefix of 常用變化 after completion
+	}{
+		{"fill empty", newData(""), conjugationsFill, true, "ます形: 飲みます<br>て形: 飲んで"},
+		{"keep inconsistent", newData("て形: 飲みて"), conjugationsFill, false, "て形: 飲みて"},
+		{"fix inconsistent", newData("て形: 飲みて"), conjugationsFix, true, "ます形: 飲みます"},
+		{"keep consistent", newData("て形: 飲んで"), conjugationsFix, false, "て形: 飲んで"},
+		{"off", newData(""), conjugationsOff, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := factory.CreateCard("verb", tt.data)
+			if err != nil {
+				t.Fatalf("CreateCard() error = %v", err)
+			}
+			data, changed, err := completeConjugations(card, tt.data, tt.mode)
+			if err != nil {
+				t.Fatalf("completeConjugations() error = %v", err)
+			}
+			if changed != tt.changed {
+				t.Errorf("completeConjugations() changed = %v, expected %v", changed, tt.changed)
+			}
+			if got, _ := data["常用變化"].(string); !strings.HasPrefix(got, tt.expected) {
+				t.Errorf("常用變化 = %q, expected prefix %q", got, tt.expected)
+			}
+			if changed && tt.data["常用變化"] == data["常用變化"] {
+				t.Error("completeConjugations() should not modify the input data")
+			}
+		})
+	}
+
+	// Unknown verb class: warn only when there is nothing to compare against
+	data := newData("")
+	data["詞性分類"] = "動詞"
+	card, _ := factory.CreateCard("verb", data)
+	if _, changed, err := completeConjugations(card, data, conjugationsFill); changed || err == nil {
+		t.Errorf("completeConjugations() = %v, %v, expected a warning", changed, err)
+	}
+
+	// Other card types are left alone
+	if _, changed, err := completeConjugations(&models.NormalWordCard{}, nil, conjugationsFix); changed || err != nil {
+		t.Errorf("completeConjugations(normal) = %v, %v", changed, err)
+	}
+}
+
+// TestCompleteConjugationsAdjective tests filling 主要變化 of adjective cards
+func TestCompleteConjugationsAdjective(t *testing.T) {
+	card := &models.AdjectiveCard{CoreWord: "いい", WordType: "い形容詞"}
+	data, changed, err := completeConjugations(card, map[string]interface{}{"核心單字": "いい"}, conjugationsFill)
+	if err != nil || !changed {
+		t.Fatalf("completeConjugations() = %v, %v, expected 主要變化 to be filled", changed, err)
+	}
+	if got, _ := data["主要變化"].(string); !strings.HasPrefix(got, "否定形: よくない<br>過去形: よかった") {
+		t.Errorf("主要變化 = %q", got)
+	}
+}
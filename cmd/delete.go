@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete [note-id...]",
+	Short: "從 Anki 刪除日文卡片",
+	Long: `刪除 Anki 中的筆記及其所有卡片。
+
+筆記可以用以下方式選取 (可同時使用):
+- 筆記 ID (位置參數)
+- Anki 搜尋語法 (--query)
+- 單字 JSON 檔案 (--file)，例如先前匯入時使用的檔案
+
+刪除前會先列出將被刪除的筆記，並要求確認；使用 --yes 可略過確認。
+
+範例:
+  anki-japanese-cli delete 1502298033753 1502298033754
+  anki-japanese-cli delete --query='deck:日文動詞 tag:bad-batch'
+  anki-japanese-cli delete --type=verb --file=verb_cards.json --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		// 載入設定
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			cmd.PrintErrf("錯誤: 無法載入設定: %v\n", err)
+			return fmt.Errorf("無法載入設定: %w", err)
+		}
+
+		// 建立 Anki 客戶端
+		client := anki.NewClient(&cfg.Anki)
+
+		// 選取筆記
+		notes, err := selectNotes(ctx, client, cmd, args)
+		if err != nil {
+			cmd.PrintErrf("錯誤: %s\n", anki.FormatError(err))
+			return err
+		}
+		if len(notes) == 0 {
+			cmd.Println("找不到符合條件的筆記")
+			return nil
+		}
+
+		// 列出將被刪除的筆記
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "以下 %d 筆筆記 (共 %d 張卡片) 將被刪除:\n", len(notes), len(noteCardIDs(notes)))
+		printNoteSummary(out, notes)
+
+		// 確認
+		if !yes {
+			fmt.Fprint(out, "\n確定要刪除嗎？此操作無法復原 [y/N]: ")
+			answer, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+			answer = strings.ToLower(strings.TrimSpace(answer))
+			if answer != "y" && answer != "yes" {
+				fmt.Fprintln(out, "已取消刪除")
+				return nil
+			}
+		}
+
+		noteIDs := make([]int64, len(notes))
+		for i, note := range notes {
+			noteIDs[i] = note.NoteID
+		}
+
+		if err := client.DeleteNotesContext(ctx, noteIDs); err != nil {
+			cmd.PrintErrf("錯誤: 無法刪除筆記: %s\n", anki.FormatError(err))
+			return fmt.Errorf("無法刪除筆記: %w", err)
+		}
+
+		fmt.Fprintf(out, "✓ 成功刪除 %d 筆筆記\n", len(noteIDs))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+
+	// 定義 flags
+	addNoteSelectionFlags(deleteCmd)
+	deleteCmd.Flags().BoolP("yes", "y", false, "不詢問直接刪除")
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/furigana"
+)
+
+// 重複卡片的處理方式 (--on-duplicate)
+const (
+	onDuplicateSkip   = "skip"
+	onDuplicateUpdate = "update"
+	onDuplicateFail   = "fail"
+	onDuplicateAllow  = "allow"
+)
+
+// validateOnDuplicate 檢查 --on-duplicate 的值
+func validateOnDuplicate(mode string) error {
+	switch mode {
+	case onDuplicateSkip, onDuplicateUpdate, onDuplicateFail, onDuplicateAllow:
+		return nil
+	}
+	return fmt.Errorf("不支援的重複處理方式: %s (可用: skip, update, fail, allow)", mode)
+}
+
+// duplicateEntry 與 Anki 既有筆記或同批次其他卡片重複的卡片
+type duplicateEntry struct {
+	Index       int     // 在批次中的位置 (從 0 開始)
+	Position    int     // 在輸入資料中的位置 (從 0 開始)
+	Key         string  // 核心單字或文法要點
+	ExistingIDs []int64 // Anki 中關鍵欄位相同的筆記 ID
+	SameBatch   int     // 同批次中先前出現的卡片在輸入資料中的位置，-1 表示無
+}
+
+// reason 說明卡片被判定為重複的原因
+func (d duplicateEntry) reason() string {
+	switch {
+	case len(d.ExistingIDs) > 0:
+		return fmt.Sprintf("Anki 中已存在 (筆記 ID: %v)", d.ExistingIDs)
+	case d.SameBatch >= 0:
+		return fmt.Sprintf("與卡片 #%d 重複", d.SameBatch+1)
+	default:
+		return "Anki 判定為重複的卡片"
+	}
+}
+
+// findDuplicates 以 canAddNotes 與關鍵欄位的 findNotes 檢查批次中的重複卡片
+// positions 為每張卡片在輸入資料中的位置；dict 用來比對以振り仮名標註的關鍵欄位；
+// seen 記錄先前各批已出現的關鍵欄位 (關鍵欄位 → 位置)，分批處理時用來找出跨批次的重複
+func findDuplicates(ctx context.Context, client *anki.Client, cardType string, notes []anki.NoteInfo, positions []int, dict *furigana.Dictionary, seen map[string]int) ([]duplicateEntry, error) {
+	if len(notes) == 0 {
+		return nil, nil
+	}
+	keyField := modelKeyField(cardType, notes[0].Fields)
+
+	// 所有檢查以單一 multi 請求送出
+	batch := client.NewBatch()
+	canAddIdx := batch.QueueCanAddNotes(notes)
+	findIdx := make([]int, len(notes))
+	for i, note := range notes {
+		query := anki.NoteTypeQuery(note.ModelName) + " " + keyFieldQuery(keyField, note.Fields[keyField], dict)
+		findIdx[i] = batch.QueueFindNotes(query)
+	}
+
+	results, err := batch.ExecuteContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	canAdd, err := results[canAddIdx].Bools()
+	if err != nil {
+		return nil, err
+	}
+	if len(canAdd) != len(notes) {
+		return nil, fmt.Errorf("canAddNotes 回傳 %d 筆結果，預期 %d 筆", len(canAdd), len(notes))
+	}
+
+	existing := make([][]int64, len(notes))
+	for i, idx := range findIdx {
+		if existing[i], err = results[idx].NoteIDs(); err != nil {
+			return nil, err
+		}
+	}
+
+	return collectDuplicates(notes, positions, keyField, canAdd, existing, seen), nil
+}
+
+// collectDuplicates 根據檢查結果整理出重複的卡片，並將這一批的關鍵欄位加入 seen
+func collectDuplicates(notes []anki.NoteInfo, positions []int, keyField string, canAdd []bool, existing [][]int64, seen map[string]int) []duplicateEntry {
+	var duplicates []duplicateEntry
+
+	for i, note := range notes {
+		key := note.Fields[keyField]
+		entry := duplicateEntry{
+			Index:       i,
+			Position:    positions[i],
+			Key:         key,
+			ExistingIDs: existing[i],
+			SameBatch:   -1,
+		}
+		if first, ok := seen[key]; ok {
+			entry.SameBatch = first
+		} else {
+			seen[key] = positions[i]
+		}
+
+		if len(entry.ExistingIDs) > 0 || entry.SameBatch >= 0 || !canAdd[i] {
+			duplicates = append(duplicates, entry)
+		}
+	}
+
+	return duplicates
+}
+
+// updateDuplicates 以新的卡片資料更新重複卡片對應的既有筆記，並將每張卡片的結果寫入 w；
+// 回傳成功更新的數量
+func updateDuplicates(ctx context.Context, client *anki.Client, w io.Writer, notes []anki.NoteInfo, duplicates []duplicateEntry) (int, error) {
+	batch := client.NewBatch()
+	var queued []duplicateEntry
+	for _, dup := range duplicates {
+		// 只有唯一對應到既有筆記的卡片才能更新
+		if len(dup.ExistingIDs) != 1 {
+			fmt.Fprintf(w, "  略過卡片 #%d %s: 無法對應到唯一的既有筆記\n", dup.Position+1, dup.Key)
+			continue
+		}
+		if dup.SameBatch >= 0 {
+			fmt.Fprintf(w, "  略過卡片 #%d %s: 與卡片 #%d 重複\n", dup.Position+1, dup.Key, dup.SameBatch+1)
+			continue
+		}
+		batch.QueueUpdateNoteFields(dup.ExistingIDs[0], notes[dup.Index].Fields)
+		queued = append(queued, dup)
+	}
+
+	if batch.Len() == 0 {
+		return 0, nil
+	}
+
+	results, err := batch.ExecuteContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for i, result := range results {
+		dup := queued[i]
+		if result.Err != nil {
+			fmt.Fprintf(w, "  錯誤: 無法更新卡片 #%d %s: %v\n", dup.Position+1, dup.Key, result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "  ✓ 已更新筆記 %d (%s)\n", dup.ExistingIDs[0], dup.Key)
+		updated++
+	}
+
+	return updated, nil
+}
+
+// removeDuplicates 從批次中移除重複的卡片，positions 為每張卡片在輸入資料中的位置
+func removeDuplicates(notes []anki.NoteInfo, positions []int, duplicates []duplicateEntry) ([]anki.NoteInfo, []int) {
+	skip := make(map[int]bool, len(duplicates))
+	for _, dup := range duplicates {
+		skip[dup.Index] = true
+	}
+
+	remaining := make([]anki.NoteInfo, 0, len(notes)-len(skip))
+	remainingPositions := make([]int, 0, len(notes)-len(skip))
+	for i, note := range notes {
+		if !skip[i] {
+			remaining = append(remaining, note)
+			remainingPositions = append(remainingPositions, positions[i])
+		}
+	}
+	return remaining, remainingPositions
+}
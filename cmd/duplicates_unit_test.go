@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/config"
+)
+
+// TestCollectDuplicates tests combining canAddNotes and findNotes results
+func TestCollectDuplicates(t *testing.T) {
+	notes := []anki.NoteInfo{
+		{Fields: map[string]string{"核心單字": "飲む"}},
+		{Fields: map[string]string{"核心單字": "食べる"}},
+		{Fields: map[string]string{"核心單字": "見る"}},
+		{Fields: map[string]string{"核心單字": "食べる"}},
+		{Fields: map[string]string{"核心單字": "行く"}},
+	}
+	canAdd := []bool{false, true, true, true, false}
+	existing := [][]int64{{1502298033753}, nil, nil, nil, nil}
+
+	duplicates := collectDuplicates(notes, []int{0, 1, 2, 3, 4}, "核心單字", canAdd, existing, make(map[string]int))
+
+	expected := []struct {
+		index     int
+		sameBatch int
+		existing  int
+	}{
+		{index: 0, sameBatch: -1, existing: 1},
+		{index: 3, sameBatch: 1, existing: 0},
+		{index: 4, sameBatch: -1, existing: 0},
+	}
+
+	if len(duplicates) != len(expected) {
+		t.Fatalf("collectDuplicates() returned %d entries, expected %d: %+v", len(duplicates), len(expected), duplicates)
+	}
+	for i, want := range expected {
+		got := duplicates[i]
+		if got.Index != want.index || got.SameBatch != want.sameBatch || len(got.ExistingIDs) != want.existing {
+			t.Errorf("duplicates[%d] = %+v, expected %+v", i, got, want)
+		}
+		if got.reason() == "" {
+			t.Errorf("duplicates[%d].reason() is empty", i)
+		}
+	}
+
+	remaining, positions := removeDuplicates(notes, []int{0, 1, 2, 3, 4}, duplicates)
+	if len(remaining) != 2 || remaining[0].Fields["核心單字"] != "食べる" || remaining[1].Fields["核心單字"] != "見る" {
+		t.Errorf("removeDuplicates() = %+v, expected 食べる and 見る", remaining)
+	}
+	if len(positions) != 2 || positions[0] != 1 || positions[1] != 2 {
+		t.Errorf("removeDuplicates() positions = %v, expected [1 2]", positions)
+	}
+}
+
+// TestValidateOnDuplicate tests the accepted --on-duplicate values
+func TestValidateOnDuplicate(t *testing.T) {
+	for _, mode := range []string{"skip", "update", "fail", "allow"} {
+		if err := validateOnDuplicate(mode); err != nil {
+			t.Errorf("validateOnDuplicate(%q) error = %v", mode, err)
+		}
+	}
+	if err := validateOnDuplicate("ignore"); err == nil {
+		t.Error("validateOnDuplicate(\"ignore\") expected error")
+	}
+}
+
+// TestUpdateDuplicates tests that updating existing notes reports every card to the given writer
+func TestUpdateDuplicates(t *testing.T) {
+	notes := []anki.NoteInfo{
+		{Fields: map[string]string{"核心單字": "飲む"}},
+		{Fields: map[string]string{"核心單字": "食べる"}},
+		{Fields: map[string]string{"核心單字": "食べる"}},
+	}
+	duplicates := []duplicateEntry{
+		{Index: 0, Position: 0, Key: "飲む", ExistingIDs: []int64{1502298033753}, SameBatch: -1},
+		{Index: 1, Position: 1, Key: "食べる", ExistingIDs: []int64{1502298033754, 1502298033755}, SameBatch: -1},
+		{Index: 2, Position: 2, Key: "食べる", ExistingIDs: []int64{1502298033756}, SameBatch: 1},
+	}
+	recorder := &multiRecorder{}
+	client := anki.NewClientWithHTTPClient(&config.AnkiConfig{ConnectURL: "http://localhost:8765"}, recorder)
+
+	var out bytes.Buffer
+	updated, err := updateDuplicates(context.Background(), client, &out, notes, duplicates)
+	if err != nil {
+		t.Fatalf("updateDuplicates() error = %v", err)
+	}
+	if updated != 1 {
+		t.Errorf("updateDuplicates() = %d, expected 1", updated)
+	}
+	if calls, _ := recorder.countActions("updateNoteFields"); calls != 1 {
+		t.Errorf("updateNoteFields was sent in %d multi calls, expected 1", calls)
+	}
+	report := out.String()
+	for _, want := range []string{"已更新筆記 1502298033753 (飲む)", "卡片 #2 食べる: 無法對應到唯一的既有筆記", "卡片 #3 食べる: 與卡片 #2 重複"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("output missing %q:\n%s", want, report)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"sort"
+
+	"anki-japanese-cli/internal/models"
+)
+
+// cardModel 取得卡片類型的定義 (內建類型或由設定目錄載入的自訂類型)
+func cardModel(cardType string) (*models.CardTypeDefinition, bool) {
+	return models.DefaultRegistry().Get(cardType)
+}
+
+// cardModelName 回傳卡片類型對應的 Anki 模型名稱
+func cardModelName(cardType string) string {
+	if def, ok := cardModel(cardType); ok {
+		return def.ModelName
+	}
+	return ""
+}
+
+// legacyFieldNames 舊版 init 建立的模型所使用的欄位名稱 (卡片欄位 → 舊模型欄位)
+var legacyFieldNames = map[string]map[string]string{
+	"normal": {
+		"詞性分類": "詞性",
+	},
+	"grammar": {
+		"文法要點": "文法點",
+		"意義說明": "核心意義",
+		"結構形式": "接續規則",
+		"使用時機": "語感說明",
+		"相關文法": "易混淆文法",
+	},
+}
+
+// mapNoteFields 將卡片欄位對應到 Anki 模型的欄位，回傳對應後的欄位與模型中不存在的欄位。
+// 模型若仍使用舊版欄位名稱會自動對應；模型中不存在的空白欄位直接忽略。
+func mapNoteFields(cardType string, fields map[string]string, modelFields []string) (map[string]string, []string) {
+	inModel := make(map[string]bool, len(modelFields))
+	for _, field := range modelFields {
+		inModel[field] = true
+	}
+
+	mapped := make(map[string]string, len(fields))
+	var unknown []string
+	for name, value := range fields {
+		if inModel[name] {
+			mapped[name] = value
+			continue
+		}
+		if legacy, ok := legacyFieldNames[cardType][name]; ok && inModel[legacy] {
+			mapped[legacy] = value
+			continue
+		}
+		if value != "" {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(unknown)
+	return mapped, unknown
+}
+
+// cardFieldName 將模型欄位名稱對應回卡片欄位名稱 (舊版欄位名稱 → 目前的欄位名稱)
+func cardFieldName(cardType, modelField string) string {
+	for field, legacy := range legacyFieldNames[cardType] {
+		if legacy == modelField {
+			return field
+		}
+	}
+	return modelField
+}
+
+// keyFieldNames 回傳關鍵欄位在模型中可能的名稱 (目前的欄位名稱與舊版欄位名稱)
+func keyFieldNames(cardType string) []string {
+	keyField := cardKeyField(cardType)
+	if legacy, ok := legacyFieldNames[cardType][keyField]; ok {
+		return []string{keyField, legacy}
+	}
+	return []string{keyField}
+}
+
+// modelKeyField 回傳筆記欄位中實際的關鍵欄位名稱 (模型使用舊版欄位名稱時為舊名稱)
+func modelKeyField(cardType string, fields map[string]string) string {
+	keyField := cardKeyField(cardType)
+	if _, ok := fields[keyField]; ok {
+		return keyField
+	}
+	if legacy, ok := legacyFieldNames[cardType][keyField]; ok {
+		if _, ok := fields[legacy]; ok {
+			return legacy
+		}
+	}
+	return keyField
+}
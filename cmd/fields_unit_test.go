@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"regexp"
+	"testing"
+
+	"anki-japanese-cli/internal/models"
+	"anki-japanese-cli/internal/templates"
+)
+
+// TestCardModelFields tests that init creates the fields add validates against
+func TestCardModelFields(t *testing.T) {
+	for _, cardType := range models.DefaultRegistry().Names() {
+		modelDef, _ := cardModel(cardType)
+		if len(modelDef.Fields) == 0 {
+			t.Fatalf("cardModel(%s) has no fields", cardType)
+		}
+		if modelDef.Fields[0] != cardKeyField(cardType) {
+			t.Errorf("cardModel(%s) first field = %s, expected key field %s", cardType, modelDef.Fields[0], cardKeyField(cardType))
+		}
+	}
+}
+
+// TestMapNoteFields tests checking card keys against the Anki model fields
+func TestMapNoteFields(t *testing.T) {
+	fields := map[string]string{
+		"文法要點": "〜ても",
+		"意義說明": "即使",
+		"記憶技巧": "",
+		"備註":   "自訂欄位",
+	}
+
+	// Current model: unknown non-empty fields are rejected, empty ones ignored
+	mapped, unknown := mapNoteFields("grammar", fields, cardModelFields("grammar"))
+	if len(unknown) != 1 || unknown[0] != "備註" {
+		t.Errorf("mapNoteFields() unknown = %v, expected [備註]", unknown)
+	}
+	if mapped["文法要點"] != "〜ても" || mapped["意義說明"] != "即使" {
+		t.Errorf("mapNoteFields() mapped = %v", mapped)
+	}
+
+	// Legacy model: renamed fields are mapped to their old names
+	legacyFields := []string{"文法點", "核心意義", "接續規則", "語感說明", "情境課題", "解答範例", "易混淆文法"}
+	delete(fields, "備註")
+	mapped, unknown = mapNoteFields("grammar", fields, legacyFields)
+	if len(unknown) != 0 {
+		t.Errorf("mapNoteFields() unknown = %v, expected none", unknown)
+	}
+	if mapped["文法點"] != "〜ても" || mapped["核心意義"] != "即使" {
+		t.Errorf("mapNoteFields() did not map to legacy fields: %v", mapped)
+	}
+	if key := modelKeyField("grammar", mapped); key != "文法點" {
+		t.Errorf("modelKeyField() = %s, expected 文法點", key)
+	}
+}
+
+// TestAnkiTemplateFields tests that the generated Anki templates only reference note-type fields
+func TestAnkiTemplateFields(t *testing.T) {
+	manager, err := templates.NewTemplateManager()
+	if err != nil {
+		t.Fatalf("Failed to create template manager: %v", err)
+	}
+
+	// Field references, optionally with a filter such as {{cloze:克漏字}}
+	fieldRef := regexp.MustCompile(`{{[#^/]?(?:[^{}:]+:)?([^{}:]+)}}`)
+	for _, cardType := range models.DefaultRegistry().Names() {
+		modelDef, _ := cardModel(cardType)
+		ankiTemplate, err := manager.GetAnkiTemplate(cardType)
+		if err != nil {
+			t.Fatalf("GetAnkiTemplate(%s) returned error: %v", cardType, err)
+		}
+
+		fields := make(map[string]bool, len(modelDef.Fields))
+		for _, field := range modelDef.Fields {
+			fields[field] = true
+		}
+
+		for _, match := range fieldRef.FindAllStringSubmatch(ankiTemplate.Front+ankiTemplate.Back, -1) {
+			if !fields[match[1]] {
+				t.Errorf("%s template references unknown field '%s'", cardType, match[1])
+			}
+		}
+	}
+}
+
+// cardModelFields returns the note-type fields of a card type
+func cardModelFields(cardType string) []string {
+	modelDef, _ := cardModel(cardType)
+	return modelDef.Fields
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/furigana"
+
+	"github.com/spf13/viper"
+)
+
+// furiganaFields 使用 --furigana 時標註振り仮名的欄位
+var furiganaFields = []string{"情境例句", "核心單字"}
+
+// loadFuriganaDictionary 載入振り仮名字典：
+// 指定 --furigana-dict (或設定檔的 furigana_dictionary) 時合併該檔案，否則使用內建字典
+func loadFuriganaDictionary(path string) (*furigana.Dictionary, error) {
+	if path == "" {
+		path = viper.GetString("furigana_dictionary")
+	}
+	if path == "" {
+		return furigana.Default(), nil
+	}
+	return furigana.LoadFile(path)
+}
+
+// annotateFurigana 為卡片資料的情境例句與核心單字加上振り仮名標註。
+// 有修改時回傳更新後的卡片資料 (不修改原本的 map)；已有標註的欄位保持不變
+func annotateFurigana(data map[string]interface{}, dict *furigana.Dictionary) (map[string]interface{}, bool) {
+	changed := false
+	for _, field := range furiganaFields {
+		text, ok := data[field].(string)
+		if !ok {
+			continue
+		}
+		if annotated := dict.Annotate(text); annotated != text {
+			data = withField(data, field, annotated)
+			changed = true
+		}
+	}
+	return data, changed
+}
+
+// keyFuriganaDictionary 回傳比對關鍵欄位用的振り仮名字典 (設定檔的 furigana_dictionary 或內建字典)；
+// 字典檔案無法載入時使用內建字典
+func keyFuriganaDictionary() *furigana.Dictionary {
+	dict, err := loadFuriganaDictionary("")
+	if err != nil {
+		return furigana.Default()
+	}
+	return dict
+}
+
+// keyFieldQuery 建立比對關鍵欄位的搜尋條件，同時比對有與沒有振り仮名標註的寫法：
+// 值包含標註時也比對未標註的寫法，未標註時也比對以 dict 標註的寫法 (以 --furigana 新增的筆記)。
+// dict 為 nil 時只比對未標註的寫法
+func keyFieldQuery(field, value string, dict *furigana.Dictionary) string {
+	forms := []string{value}
+	plain := furigana.Strip(value)
+	if plain != value {
+		forms = append(forms, plain)
+	}
+	if dict != nil {
+		if annotated := dict.Annotate(plain); annotated != plain && annotated != value {
+			forms = append(forms, annotated)
+		}
+	}
+
+	if len(forms) == 1 {
+		return anki.FieldQuery(field, value)
+	}
+	queries := make([]string, len(forms))
+	for i, form := range forms {
+		queries[i] = anki.FieldQuery(field, form)
+	}
+	return "(" + strings.Join(queries, " OR ") + ")"
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"anki-japanese-cli/internal/furigana"
+)
+
+// TestAnnotateFurigana tests annotating 情境例句 and 核心單字 without touching other fields
+func TestAnnotateFurigana(t *testing.T) {
+	dict := furigana.NewDictionary()
+	dict.Add("水", "みず")
+	dict.Add("飲む", "のむ")
+
+	data := map[string]interface{}{"核心單字": "飲む", "情境例句": "水を飲む", "例句翻譯": "喝水"}
+	annotated, changed := annotateFurigana(data, dict)
+	if !changed {
+		t.Fatal("annotateFurigana() expected a change")
+	}
+	if got := annotated["核心單字"]; got != "飲[の]む" {
+		t.Errorf("核心單字 = %v, expected 飲[の]む", got)
+	}
+	if got := annotated["情境例句"]; got != "水[みず]を 飲[の]む" {
+		t.Errorf("情境例句 = %v, expected 水[みず]を 飲[の]む", got)
+	}
+	if data["情境例句"] != "水を飲む" {
+		t.Error("annotateFurigana() modified the original data")
+	}
+
+	if _, changed := annotateFurigana(annotated, dict); changed {
+		t.Error("annotateFurigana() should leave annotated fields unchanged")
+	}
+}
+
+// TestKeyFieldQuery tests matching both the annotated and the plain key
+func TestKeyFieldQuery(t *testing.T) {
+	dict := furigana.NewDictionary()
+	dict.Add("飲む", "のむ")
+
+	if got := keyFieldQuery("核心單字", "飲む", nil); got != `"核心單字:飲む"` {
+		t.Errorf("keyFieldQuery() = %s", got)
+	}
+	if got := keyFieldQuery("核心單字", "飲[の]む", dict); got != `("核心單字:飲[の]む" OR "核心單字:飲む")` {
+		t.Errorf("keyFieldQuery() = %s", got)
+	}
+
+	// A plain key also finds notes added with --furigana
+	if got := keyFieldQuery("核心單字", "飲む", dict); got != `("核心單字:飲む" OR "核心單字:飲[の]む")` {
+		t.Errorf("keyFieldQuery() plain = %s", got)
+	}
+	if got := keyFieldQuery("核心單字", "のむ", dict); got != `"核心單字:のむ"` {
+		t.Errorf("keyFieldQuery() kana = %s", got)
+	}
+}
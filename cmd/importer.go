@@ -0,0 +1,397 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/furigana"
+	"anki-japanese-cli/internal/models"
+)
+
+// addChunkSize 每次檢查重複與新增的筆記數量：大型檔案分批處理，
+// 同時只有一批筆記保留在記憶體中，也不會產生過大的 multi 請求
+const addChunkSize = 500
+
+// cardImporter 逐筆讀取卡片資料，分批檢查重複並新增到 Anki。
+// 卡片資料讀取兩次：check 驗證所有卡片 (--on-duplicate=fail 時也檢查重複)，
+// 全部通過後 add 才開始新增，因此有錯誤時不會只新增一部分的卡片
+type cardImporter struct {
+	ctx    context.Context
+	client *anki.Client
+	out    io.Writer
+
+	factory          *models.CardFactory
+	cardType         string
+	clozeFrom        string
+	deckName         string
+	modelName        string
+	modelFields      []string
+	conjugationsMode string
+	dict             *furigana.Dictionary // --furigana 使用的字典，nil 表示不標註
+	keyDict          *furigana.Dictionary // 比對以振り仮名標註的關鍵欄位
+	onDuplicate      string
+	batchMode        bool
+	failed           *failedWriter // --failed-output，nil 表示不輸出
+	chunkSize        int
+
+	// check 的結果
+	total       int
+	completed   int
+	annotated   int
+	invalid     []cardIssues
+	warned      []cardIssues // 有警告的卡片 (最多 maxReportedWarnings 張)
+	warnedCount int          // 有警告的卡片總數
+	duplicates  []duplicateEntry
+
+	// add 的結果
+	added     int
+	addFailed int
+	deckReady bool
+}
+
+// noteChunk 一批待處理的筆記；positions 為每個筆記在輸入資料中的位置，
+// entries 為使用者提交的原始資料 (依位置，只在指定 --failed-output 時保留)
+type noteChunk struct {
+	notes     []anki.NoteInfo
+	positions []int
+	entries   map[int]map[string]interface{}
+	failed    map[int]map[string]interface{} // 這一批中失敗的卡片 (驗證失敗或新增失敗)
+}
+
+func newNoteChunk() *noteChunk {
+	return &noteChunk{
+		entries: make(map[int]map[string]interface{}),
+		failed:  make(map[int]map[string]interface{}),
+	}
+}
+
+// preparedCard 轉換後的卡片
+type preparedCard struct {
+	note      anki.NoteInfo
+	warned    *cardIssues // 不影響新增的警告 (沒有警告時為 nil)
+	invalid   *cardIssues // 驗證錯誤 (通過驗證時為 nil)
+	completed bool        // 自動產生了常用變化或主要變化
+	annotated bool        // 加上了振り仮名標註
+}
+
+// prepareCard 將一筆卡片資料轉換為 Anki 筆記：正規化發音、產生克漏字與變化、標註振り仮名並驗證
+func (im *cardImporter) prepareCard(position int, raw map[string]interface{}) preparedCard {
+	var prepared preparedCard
+	reject := func(cardType string, data map[string]interface{}, issues []error) preparedCard {
+		issue := newCardIssues(position, cardType, data, issues)
+		return preparedCard{invalid: &issue}
+	}
+
+	// 發音統一為全形假名，羅馬拼音轉換為平假名 (taberu → たべる)
+	data, _ := normalizeReading(raw)
+
+	// 由動詞或一般單字卡片的資料產生克漏字卡片
+	if im.clozeFrom != "" {
+		clozeCard, err := im.factory.CreateClozeFromCard(im.clozeFrom, data)
+		if err != nil {
+			return reject(im.clozeFrom, data, validationErrorList(err))
+		}
+		data = clozeCard.ToMap()
+	}
+
+	// 驗證卡片資料，收集整個批次的錯誤後再一併回報
+	card, err := im.factory.CreateCard(im.cardType, data)
+	if err != nil {
+		return reject(im.cardType, data, validationErrorList(err))
+	}
+
+	// 動詞的常用變化與形容詞的主要變化為空 (或 --conjugations=fix 時不一致) 時自動產生
+	var warnings []error
+	data, prepared.completed, err = completeConjugations(card, data, im.conjugationsMode)
+	if err != nil {
+		warnings = append(warnings, err)
+	}
+
+	// 為情境例句與核心單字加上振り仮名標註
+	if im.dict != nil {
+		data, prepared.annotated = annotateFurigana(data, im.dict)
+	}
+
+	// 檢查欄位是否存在於 Anki 模型
+	fields, unknown := mapNoteFields(im.cardType, noteFieldsFromData(data), im.modelFields)
+	if len(unknown) > 0 {
+		var issues []error
+		for _, field := range unknown {
+			issues = append(issues, models.NewValidationError(field, fmt.Sprintf("不是模型 '%s' 的欄位", im.modelName)))
+		}
+		return reject(im.cardType, data, issues)
+	}
+	warnings = append(warnings, card.Validate().Warnings().Unwrap()...)
+	if len(warnings) > 0 {
+		issue := newCardIssues(position, im.cardType, data, warnings)
+		prepared.warned = &issue
+	}
+
+	prepared.note = anki.NoteInfo{
+		DeckName:  im.deckName,
+		ModelName: im.modelName,
+		Fields:    fields,
+		Tags:      []string{"anki-japanese-cli", im.cardType},
+	}
+	return prepared
+}
+
+// readChunks 逐筆讀取卡片資料，每累積 chunkSize 個有效筆記呼叫一次 flush (最後一批在讀取結束時)。
+// record 為 true 時記錄驗證結果與統計 (check)；否則略過驗證失敗的卡片，只保留其原始資料供 --failed-output 輸出
+func (im *cardImporter) readChunks(source cardSource, record bool, flush func(chunk *noteChunk) error) error {
+	chunk := newNoteChunk()
+	for position := 0; ; position++ {
+		raw, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("卡片 #%d: %w", position+1, err)
+		}
+		prepared := im.prepareCard(position, raw)
+		if record {
+			im.record(prepared)
+		}
+		if prepared.invalid != nil {
+			if !record && im.failed != nil {
+				chunk.failed[position] = raw
+			}
+			continue
+		}
+
+		chunk.notes = append(chunk.notes, prepared.note)
+		chunk.positions = append(chunk.positions, position)
+		if im.failed != nil {
+			chunk.entries[position] = raw
+		}
+		if len(chunk.notes) >= im.chunkSize {
+			if err := flush(chunk); err != nil {
+				return err
+			}
+			chunk = newNoteChunk()
+		}
+	}
+	if len(chunk.notes) == 0 && len(chunk.failed) == 0 {
+		return nil
+	}
+	return flush(chunk)
+}
+
+// record 記錄一張卡片的驗證結果與統計
+func (im *cardImporter) record(prepared preparedCard) {
+	im.total++
+	if prepared.completed {
+		im.completed++
+	}
+	if prepared.annotated {
+		im.annotated++
+	}
+	if prepared.invalid != nil {
+		im.invalid = append(im.invalid, *prepared.invalid)
+	}
+	if prepared.warned != nil {
+		im.warnedCount++
+		if len(im.warned) < maxReportedWarnings {
+			im.warned = append(im.warned, *prepared.warned)
+		}
+	}
+}
+
+// check 驗證所有卡片資料；--on-duplicate=fail 時也分批檢查重複的卡片 (不新增任何卡片)
+func (im *cardImporter) check(source cardSource) error {
+	seen := make(map[string]int)
+	return im.readChunks(source, true, func(chunk *noteChunk) error {
+		if im.onDuplicate != onDuplicateFail || len(chunk.notes) == 0 {
+			return nil
+		}
+		duplicates, err := findDuplicates(im.ctx, im.client, im.cardType, chunk.notes, chunk.positions, im.keyDict, seen)
+		if err != nil {
+			return fmt.Errorf("無法檢查重複卡片: %w", err)
+		}
+		im.duplicates = append(im.duplicates, duplicates...)
+		return nil
+	})
+}
+
+// add 分批新增卡片：每一批檢查重複、新增並輸出失敗的卡片後即釋放
+func (im *cardImporter) add(source cardSource) error {
+	seen := make(map[string]int)
+	return im.readChunks(source, false, func(chunk *noteChunk) error {
+		if err := im.addChunk(chunk, seen); err != nil {
+			return err
+		}
+		return im.writeFailed(chunk.failed)
+	})
+}
+
+// addChunk 處理一批筆記：依 --on-duplicate 處理重複的卡片後新增到 Anki
+func (im *cardImporter) addChunk(chunk *noteChunk, seen map[string]int) error {
+	notes, positions := chunk.notes, chunk.positions
+	if len(notes) == 0 {
+		return nil
+	}
+
+	switch im.onDuplicate {
+	case onDuplicateAllow:
+		for i := range notes {
+			notes[i].Options = map[string]interface{}{"allowDuplicate": true}
+		}
+	case onDuplicateSkip, onDuplicateUpdate:
+		duplicates, err := findDuplicates(im.ctx, im.client, im.cardType, notes, positions, im.keyDict, seen)
+		if err != nil {
+			return fmt.Errorf("無法檢查重複卡片: %w", err)
+		}
+		if len(duplicates) > 0 {
+			fmt.Fprintf(im.out, "發現 %d 張重複的卡片:\n", len(duplicates))
+			printDuplicates(im.out, duplicates)
+			if im.onDuplicate == onDuplicateUpdate {
+				fmt.Fprintln(im.out, "正在更新既有筆記...")
+				updated, err := updateDuplicates(im.ctx, im.client, im.out, notes, duplicates)
+				if err != nil {
+					return fmt.Errorf("無法更新既有筆記: %w", err)
+				}
+				fmt.Fprintf(im.out, "✓ 已更新 %d 筆既有筆記\n", updated)
+			} else {
+				fmt.Fprintf(im.out, "略過 %d 張重複的卡片\n", len(duplicates))
+			}
+			notes, positions = removeDuplicates(notes, positions, duplicates)
+		}
+	}
+	if len(notes) == 0 {
+		return nil
+	}
+
+	// 確保牌組存在 (第一次新增前)
+	if !im.deckReady {
+		fmt.Fprintf(im.out, "確保牌組 '%s' 存在...\n", im.deckName)
+		if _, err := im.client.CreateDeckContext(im.ctx, im.deckName); err != nil {
+			return fmt.Errorf("無法確保牌組存在: %w", err)
+		}
+		fmt.Fprintf(im.out, "✓ 牌組 '%s' 已就緒\n", im.deckName)
+		im.deckReady = true
+	}
+
+	// 單一卡片模式
+	if !im.batchMode && im.total == 1 {
+		fmt.Fprintln(im.out, "正在新增卡片到 Anki...")
+		noteID, err := im.client.AddNoteContext(im.ctx, notes[0])
+		if err != nil {
+			var dupErr *anki.DuplicateNoteError
+			if errors.As(err, &dupErr) {
+				fmt.Fprintln(im.out, "錯誤: Anki 中已存在相同的卡片")
+			}
+			return fmt.Errorf("無法新增卡片: %w", err)
+		}
+		im.added++
+		fmt.Fprintf(im.out, "✓ 成功新增卡片 (ID: %d)\n", noteID)
+		return nil
+	}
+
+	// 批次模式
+	fmt.Fprintf(im.out, "正在批次新增 %d 張卡片到 Anki...\n", len(notes))
+	results, err := im.client.AddNotesWithResultsContext(im.ctx, notes)
+	if err != nil {
+		return fmt.Errorf("無法批次新增卡片: %w", err)
+	}
+
+	// 列出每張卡片的結果；新增失敗的卡片輸出使用者提交的原始資料，修正後可直接再次匯入
+	addFailed := printAddResults(im.out, im.cardType, positions, results)
+	im.added += len(results) - len(addFailed)
+	im.addFailed += len(addFailed)
+	for _, position := range addFailed {
+		if entry, ok := chunk.entries[position]; ok {
+			chunk.failed[position] = entry
+		}
+	}
+	return nil
+}
+
+// writeFailed 依在輸入資料中的位置將一批失敗的卡片寫入 --failed-output
+func (im *cardImporter) writeFailed(failed map[int]map[string]interface{}) error {
+	if im.failed == nil || len(failed) == 0 {
+		return nil
+	}
+	positions := make([]int, 0, len(failed))
+	for position := range failed {
+		positions = append(positions, position)
+	}
+	sort.Ints(positions)
+	entries := make([]map[string]interface{}, 0, len(positions))
+	for _, position := range positions {
+		entries = append(entries, failed[position])
+	}
+	if err := im.failed.Write(entries); err != nil {
+		return fmt.Errorf("無法寫入失敗的卡片: %w", err)
+	}
+	return nil
+}
+
+// printDuplicates 列出重複的卡片與原因
+func printDuplicates(w io.Writer, duplicates []duplicateEntry) {
+	for _, dup := range duplicates {
+		fmt.Fprintf(w, "  卡片 #%d %s: %s\n", dup.Position+1, dup.Key, dup.reason())
+	}
+}
+
+// failedWriter 將失敗的卡片資料逐批寫入新的 JSON 檔案 (JSON 陣列，格式與輸入檔案相同)；
+// 檔案在開始匯入前建立，不覆寫既有檔案
+type failedWriter struct {
+	path  string
+	file  *os.File
+	count int
+}
+
+// newFailedWriter 建立失敗卡片的輸出檔案；檔案已存在時回傳錯誤，避免新增到一半才中止
+func newFailedWriter(path string) (*failedWriter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &failedWriter{path: path, file: file}, nil
+}
+
+// Write 寫入一批失敗的卡片資料
+func (w *failedWriter) Write(entries []map[string]interface{}) error {
+	for _, entry := range entries {
+		content, err := json.MarshalIndent(entry, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		separator := ",\n  "
+		if w.count == 0 {
+			separator = "[\n  "
+		}
+		if _, err := w.file.WriteString(separator); err != nil {
+			return err
+		}
+		if _, err := w.file.Write(content); err != nil {
+			return err
+		}
+		w.count++
+	}
+	return nil
+}
+
+// Close 結束 JSON 陣列並關閉檔案；沒有寫入任何卡片時刪除檔案。重複呼叫不會有作用
+func (w *failedWriter) Close() error {
+	file := w.file
+	if file == nil {
+		return nil
+	}
+	w.file = nil
+	if w.count == 0 {
+		file.Close()
+		return os.Remove(w.path)
+	}
+	if _, err := file.WriteString("\n]\n"); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/config"
+	"anki-japanese-cli/internal/models"
+)
+
+// multiRecorder answers AnkiConnect requests and records the actions of every multi call
+type multiRecorder struct {
+	mu     sync.Mutex
+	multis [][]string // action names of each multi call
+	nextID int64
+}
+
+type recordedAction struct {
+	Action string          `json:"action"`
+	Params json.RawMessage `json:"params"`
+}
+
+func (r *multiRecorder) Do(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var request recordedAction
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return nil, err
+	}
+	var result interface{}
+	if request.Action == "multi" {
+		var params struct {
+			Actions []recordedAction `json:"actions"`
+		}
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return nil, err
+		}
+		var names []string
+		var results []interface{}
+		for _, action := range params.Actions {
+			names = append(names, action.Action)
+			results = append(results, map[string]interface{}{"result": r.answer(action), "error": nil})
+		}
+		r.multis = append(r.multis, names)
+		result = results
+	} else {
+		result = r.answer(request)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{"result": result, "error": nil})
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func (r *multiRecorder) answer(action recordedAction) interface{} {
+	switch action.Action {
+	case "canAddNotes":
+		var params struct {
+			Notes []json.RawMessage `json:"notes"`
+		}
+		json.Unmarshal(action.Params, &params)
+		canAdd := make([]bool, len(params.Notes))
+		for i := range canAdd {
+			canAdd[i] = true
+		}
+		return canAdd
+	case "findNotes":
+		return []int64{}
+	case "addNote":
+		r.nextID++
+		return r.nextID
+	}
+	return 1
+}
+
+// countActions returns the number of multi calls containing action and the largest multi call
+func (r *multiRecorder) countActions(action string) (calls, largest int) {
+	for _, names := range r.multis {
+		for _, name := range names {
+			if name == action {
+				calls++
+				break
+			}
+		}
+		largest = max(largest, len(names))
+	}
+	return calls, largest
+}
+
+// TestCardImporterChunks tests that a large NDJSON input is checked and added in several chunks
+func TestCardImporterChunks(t *testing.T) {
+	const total = 1200
+	var input strings.Builder
+	for i := 0; i < total; i++ {
+		card := map[string]interface{}{
+			"核心單字": fmt.Sprintf("単語%d", i),
+			"核心意義": "單字",
+			"發音":   "たんご",
+			"情境例句": "単語を覚える。",
+			"例句翻譯": "背單字。",
+		}
+		if i == 7 {
+			delete(card, "核心意義")
+		}
+		line, _ := json.Marshal(card)
+		input.Write(append(line, '\n'))
+	}
+
+	factory := models.NewCardFactory()
+	modelFields, err := factory.FieldNames("normal")
+	if err != nil {
+		t.Fatalf("FieldNames() error = %v", err)
+	}
+	recorder := &multiRecorder{}
+	failedPath := t.TempDir() + "/failed.json"
+	failed, err := newFailedWriter(failedPath)
+	if err != nil {
+		t.Fatalf("newFailedWriter() error = %v", err)
+	}
+	var out bytes.Buffer
+	im := &cardImporter{
+		ctx:         context.Background(),
+		client:      anki.NewClientWithHTTPClient(&config.AnkiConfig{ConnectURL: "http://localhost:8765"}, recorder),
+		out:         &out,
+		factory:     factory,
+		cardType:    "normal",
+		deckName:    "日文單字",
+		modelName:   cardModelName("normal"),
+		modelFields: modelFields,
+		onDuplicate: onDuplicateSkip,
+		batchMode:   true,
+		failed:      failed,
+		chunkSize:   addChunkSize,
+	}
+
+	open := func() cardSource {
+		source, err := newCardSource(strings.NewReader(input.String()), inputFormatNDJSON, true, nil)
+		if err != nil {
+			t.Fatalf("newCardSource() error = %v", err)
+		}
+		return source
+	}
+	if err := im.check(open()); err != nil {
+		t.Fatalf("check() error = %v", err)
+	}
+	if im.total != total || len(im.invalid) != 1 {
+		t.Fatalf("check() total = %d, invalid = %d, expected %d and 1", im.total, len(im.invalid), total)
+	}
+	// Every valid card lacks 圖片提示, but only the first warnings are kept for the report
+	if im.warnedCount != total-1 || len(im.warned) != maxReportedWarnings {
+		t.Errorf("check() warnedCount = %d, kept %d warnings, expected %d and %d", im.warnedCount, len(im.warned), total-1, maxReportedWarnings)
+	}
+	if len(recorder.multis) != 0 {
+		t.Errorf("check() sent %d multi calls with --on-duplicate=skip, expected none", len(recorder.multis))
+	}
+
+	if err := im.add(open()); err != nil {
+		t.Fatalf("add() error = %v", err)
+	}
+	if err := im.failed.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if im.added != total-1 {
+		t.Errorf("add() added = %d, expected %d", im.added, total-1)
+	}
+
+	addCalls, largest := recorder.countActions("addNote")
+	if addCalls < 2 {
+		t.Errorf("addNote actions were sent in %d multi calls, expected more than one", addCalls)
+	}
+	if checkCalls, _ := recorder.countActions("canAddNotes"); checkCalls != addCalls {
+		t.Errorf("duplicate checks were sent in %d multi calls, expected %d", checkCalls, addCalls)
+	}
+	if largest > addChunkSize+1 {
+		t.Errorf("largest multi call has %d actions, expected at most %d", largest, addChunkSize+1)
+	}
+
+	// The invalid card is written to --failed-output as submitted
+	content, err := os.ReadFile(failedPath)
+	if err != nil {
+		t.Fatalf("failed to read failed output: %v", err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal(content, &entries); err != nil {
+		t.Fatalf("failed output is not a JSON array: %v", err)
+	}
+	if len(entries) != 1 || entries[0]["核心單字"] != "単語7" || len(entries[0]) != 4 {
+		t.Errorf("failed output = %v, expected the submitted 単語7 entry", entries)
+	}
+}
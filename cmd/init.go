@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
+	"text/tabwriter"
 
 	"anki-japanese-cli/internal/anki"
 	"anki-japanese-cli/internal/config"
@@ -10,145 +13,109 @@ import (
 	"anki-japanese-cli/internal/templates"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
-// CSS 樣式
-const defaultCSS = `
-/* 共通樣式 */
-.card {
-  font-family: "Hiragino Sans", "Yu Gothic", "Meiryo", sans-serif;
-  background: linear-gradient(135deg, #f5f7fa 0%, #c3cfe2 100%);
-  padding: 20px;
-  border-radius: 10px;
-  box-shadow: 0 4px 15px rgba(0,0,0,0.1);
-}
-
-.card-front, .card-back {
-  text-align: center;
-  max-width: 500px;
-  margin: 0 auto;
-}
-
-/* 正面樣式 */
-.context-sentence {
-  font-size: 1.4em;
-  line-height: 1.6;
-  margin-bottom: 15px;
-  color: #2c3e50;
-  font-weight: 500;
-}
-
-.meaning-hint, .grammar-point-hint {
-  font-size: 1em;
-  color: #7f8c8d;
-  margin-bottom: 10px;
-  font-style: italic;
-}
-
-/* 背面樣式 */
-.core-word, .grammar-point {
-  font-size: 2em;
-  color: #e74c3c;
-  font-weight: bold;
-  margin-bottom: 10px;
-}
-
-.word-info {
-  display: flex;
-  justify-content: center;
-  gap: 15px;
-  margin-bottom: 15px;
-  flex-wrap: wrap;
-}
+// init 的模型狀態
+const (
+	initStatusCreated  = "已建立"
+	initStatusPresent  = "已存在"
+	initStatusUpgraded = "已更新"
+	initStatusUpToDate = "已是最新"
+	initStatusCreate   = "將建立"
+	initStatusUpgrade  = "需要更新"
+	initStatusFailed   = "失敗"
+)
 
-.pronunciation, .accent, .word-type {
-  background: #3498db;
-  color: white;
-  padding: 5px 10px;
-  border-radius: 5px;
-  font-size: 0.9em;
+// initItem 單一卡片類型的初始化狀態與結果
+type initItem struct {
+	CardType    string
+	Model       string
+	Deck        string
+	Status      string // 模型狀態
+	DeckStatus  string // 牌組狀態
+	Err         error
+	config      anki.ModelConfig
+	upgrade     *modelUpgrade
+	modelIdx    int      // createModel 在批次中的位置，-1 表示不建立
+	upgradeIdx  int      // 第一個更新動作在批次中的位置
+	upgradeStep []string // 更新動作的說明
+	deckIdx     int
 }
 
-.translation, .answer {
-  font-size: 1.2em;
-  color: #27ae60;
-  margin-bottom: 15px;
-  font-weight: 500;
+// fail 記錄初始化失敗的原因
+func (item *initItem) fail(err error) {
+	item.Status = initStatusFailed
+	item.Err = err
 }
 
-.conjugations, .related-words, .confusing-grammar {
-  background: #ecf0f1;
-  padding: 10px;
-  border-radius: 5px;
-  margin-bottom: 10px;
-  font-size: 0.9em;
-  color: #34495e;
+// parseInitArgs 將 init 的參數展開為卡片類型列表 ("all" 代表所有類型，重複的類型只保留一次)
+func parseInitArgs(factory *models.CardFactory, args []string) ([]string, error) {
+	var cardTypes []string
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		arg = strings.ToLower(arg)
+		candidates := []string{arg}
+		if arg == "all" {
+			candidates = factory.GetSupportedCardTypes()
+		} else if err := factory.ValidateCardType(arg); err != nil {
+			return nil, err
+		}
+		for _, cardType := range candidates {
+			if !seen[cardType] {
+				seen[cardType] = true
+				cardTypes = append(cardTypes, cardType)
+			}
+		}
+	}
+	return cardTypes, nil
 }
 
-.grammar-info {
-  text-align: left;
-  background: #f8f9fa;
-  padding: 15px;
-  border-radius: 8px;
-  margin-bottom: 10px;
-}
+// resolveDirections 整理要額外產生的卡片方向 (小寫、去除重複，"none" 代表不產生)；
+// 回傳卡片類型支援的方向與不支援而略過的方向
+func resolveDirections(modelDef *models.CardTypeDefinition, requested []string) ([]string, []string, error) {
+	var directions, skipped []string
+	seen := make(map[string]bool)
+	for _, direction := range requested {
+		direction = strings.ToLower(strings.TrimSpace(direction))
+		if direction == "" || direction == "none" || seen[direction] {
+			continue
+		}
+		seen[direction] = true
 
-.connection-rules, .usage-notes {
-  margin: 8px 0;
-  font-size: 0.95em;
-  line-height: 1.4;
+		if err := templates.ValidateDirection(direction); err != nil {
+			return nil, nil, err
+		}
+		if !modelDef.SupportsDirection(direction) {
+			skipped = append(skipped, direction)
+			continue
+		}
+		directions = append(directions, direction)
+	}
+	return directions, skipped, nil
 }
 
-.image-hint img, .image img {
-  max-width: 200px;
-  border-radius: 8px;
-  box-shadow: 0 2px 8px rgba(0,0,0,0.2);
-}
-`
-
-// 卡片模型定義
-var cardModels = map[string]struct {
-	Name   string
-	Fields []string
-	Deck   string
-}{
-	"verb": {
-		Name: "Japanese Verb",
-		Fields: []string{
-			"核心單字", "詞性分類", "核心意義", "發音", "重音",
-			"常用變化", "情境例句", "例句翻譯", "圖片提示",
-		},
-		Deck: "日文動詞",
-	},
-	"adjective": {
-		Name: "Japanese Adjective",
-		Fields: []string{
-			"核心單字", "詞性分類", "核心意義", "發音", "重音",
-			"主要變化", "情境例句", "例句翻譯", "相關詞彙",
-		},
-		Deck: "日文形容詞",
-	},
-	"normal": {
-		Name: "Japanese Normal Word",
-		Fields: []string{
-			"核心單字", "詞性", "核心意義", "發音", "重音",
-			"情境例句", "例句翻譯", "相關詞彙", "圖片提示",
-		},
-		Deck: "日文單字",
-	},
-	"grammar": {
-		Name: "Japanese Grammar",
-		Fields: []string{
-			"文法點", "核心意義", "接續規則", "語感說明",
-			"情境課題", "解答範例", "易混淆文法",
-		},
-		Deck: "日文文法",
-	},
+// printInitSummary 以表格列出每個卡片類型的初始化結果
+func printInitSummary(w io.Writer, items []*initItem) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "類型\t模型\t狀態\t牌組\t牌組狀態")
+	for _, item := range items {
+		status := item.Status
+		if item.Err != nil {
+			status += ": " + item.Err.Error()
+		}
+		deckStatus := item.DeckStatus
+		if deckStatus == "" {
+			deckStatus = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.CardType, item.Model, status, item.Deck, deckStatus)
+	}
+	tw.Flush()
 }
 
 // initCmd represents the init command
 var initCmd = &cobra.Command{
-	Use:   "init [card-type]",
+	Use:   "init [card-type...]",
 	Short: "初始化 Anki 卡片模型",
 	Long: `初始化 Anki 卡片模型和牌組。
 
@@ -157,18 +124,78 @@ var initCmd = &cobra.Command{
 - verb: 動詞卡片
 - adjective: 形容詞卡片
 - normal: 一般單字卡片
-- grammar: 文法卡片`,
-	Args: cobra.ExactArgs(1),
+- grammar: 文法卡片
+- kanji: 漢字卡片
+- cloze: 克漏字卡片 (Anki Cloze 模型)
+- all: 所有卡片類型 (包含設定目錄中的自訂類型)
+
+可一次指定多個類型 (例如 init verb grammar)，所有類型會在同一次連線中
+處理，最後列出每個模型與牌組的結果。
+
+verb、adjective 與 normal 可使用 --directions 讓每筆筆記額外產生其他方向的卡片:
+- production: 中→日，看中文意思回想日文
+- reading: 讀音，看漢字回想發音
+- listening: 聽力，只播放音檔 (音檔欄位為空的筆記不會產生這張卡片)
+未指定時使用設定檔的 card_directions (依卡片類型設定)；--directions none 表示只產生主要卡片。
+
+模型已存在時，使用 --upgrade 將欄位、卡片模板與 CSS 更新為目前的定義
+(舊版欄位會重新命名以保留內容，缺少的欄位會自動新增)；
+使用 --dry-run 只列出差異而不修改 Anki。`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cardType := strings.ToLower(args[0])
+		ctx := cmd.Context()
 
 		// 驗證卡片類型
 		factory := models.NewCardFactory()
-		if err := factory.ValidateCardType(cardType); err != nil {
+		cardTypes, err := parseInitArgs(factory, args)
+		if err != nil {
 			cmd.PrintErrf("錯誤: %v\n", err)
 			return err
 		}
 
+		upgrade, _ := cmd.Flags().GetBool("upgrade")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		directionsFlag, _ := cmd.Flags().GetStringSlice("directions")
+
+		// 取得模型定義，並由嵌入的 HTML 模板產生模型設定
+		items := make([]*initItem, 0, len(cardTypes))
+		for _, cardType := range cardTypes {
+			modelDef, exists := cardModel(cardType)
+			if !exists {
+				cmd.PrintErrf("錯誤: 找不到卡片類型 '%s' 的定義\n", cardType)
+				return fmt.Errorf("找不到卡片類型 '%s' 的定義", cardType)
+			}
+
+			// --directions 優先於設定檔的 card_directions
+			requested := directionsFlag
+			if !cmd.Flags().Changed("directions") {
+				requested = viper.GetStringSlice("card_directions." + cardType)
+			}
+			directions, skipped, err := resolveDirections(modelDef, requested)
+			if err != nil {
+				cmd.PrintErrf("錯誤: %v\n", err)
+				return err
+			}
+			if len(skipped) > 0 {
+				cmd.Printf("注意: 卡片類型 '%s' 不支援卡片方向 %s，已略過\n", cardType, strings.Join(skipped, ", "))
+			}
+
+			modelConfig, err := buildModelConfig(cardType, directions)
+			if err != nil {
+				cmd.PrintErrf("錯誤: %v\n", err)
+				return err
+			}
+
+			items = append(items, &initItem{
+				CardType: cardType,
+				Model:    modelDef.ModelName,
+				Deck:     modelDef.Deck,
+				config:   modelConfig,
+				modelIdx: -1,
+				deckIdx:  -1,
+			})
+		}
+
 		// 載入設定
 		cfg, err := config.LoadConfig()
 		if err != nil {
@@ -179,173 +206,247 @@ var initCmd = &cobra.Command{
 		// 建立 Anki 客戶端
 		client := anki.NewClient(&cfg.Anki)
 
-		// 檢查 Anki Connect 連線狀態
+		// 以單一 multi 請求檢查連線並取得模型列表
 		cmd.Println("檢查 Anki Connect 連線狀態...")
-		if err := client.Ping(); err != nil {
+		checkBatch := client.NewBatch()
+		versionIdx := checkBatch.Add("version", nil)
+		modelsIdx := checkBatch.Add("modelNames", nil)
+
+		checkResults, err := checkBatch.ExecuteContext(ctx)
+		if err == nil {
+			err = checkResults[versionIdx].Err
+		}
+		if err != nil {
 			cmd.PrintErrf("錯誤: 無法連線到 Anki: %v\n", err)
 			cmd.Println("請確認 Anki 已啟動且已安裝 AnkiConnect 插件。")
 			return fmt.Errorf("無法連線到 Anki: %w", err)
 		}
 		cmd.Println("✓ 成功連線到 Anki")
 
-		// 取得模型定義
-		modelDef, exists := cardModels[cardType]
-		if !exists {
-			cmd.PrintErrf("錯誤: 找不到卡片類型 '%s' 的定義\n", cardType)
-			return fmt.Errorf("找不到卡片類型 '%s' 的定義", cardType)
-		}
-
 		// 檢查模型是否已存在
-		exists, err = client.ModelExists(modelDef.Name)
+		modelNames, err := checkResults[modelsIdx].Strings()
 		if err != nil {
 			cmd.PrintErrf("錯誤: 檢查模型時發生錯誤: %v\n", err)
 			return fmt.Errorf("檢查模型時發生錯誤: %w", err)
 		}
+		existing := make(map[string]bool, len(modelNames))
+		for _, name := range modelNames {
+			existing[name] = true
+		}
 
-		if exists {
-			cmd.Printf("模型 '%s' 已存在\n", modelDef.Name)
-		} else {
-			// 建立模型
-			cmd.Printf("正在建立模型 '%s'...\n", modelDef.Name)
-
-			// 載入模板
-			templateManager, err := templates.NewTemplateManager()
-			if err != nil {
-				cmd.PrintErrf("錯誤: 無法初始化模板管理器: %v\n", err)
-				return fmt.Errorf("無法初始化模板管理器: %w", err)
+		// 建立模型、更新模型與建立牌組的動作合併為單一 multi 請求送出
+		setupBatch := client.NewBatch()
+
+		for _, item := range items {
+			if !existing[item.Model] {
+				if dryRun {
+					item.Status = initStatusCreate
+					cmd.Printf("模型 '%s' 不存在，將以目前的定義建立\n", item.Model)
+					continue
+				}
+				cmd.Printf("正在建立模型 '%s'...\n", item.Model)
+				item.modelIdx = setupBatch.QueueCreateModel(item.config)
+				item.deckIdx = setupBatch.QueueCreateDeck(item.Deck)
+				continue
 			}
 
-			// 驗證模板
-			if err := templateManager.ValidateTemplate(cardType); err != nil {
-				cmd.PrintErrf("錯誤: 模板驗證失敗: %v\n", err)
-				return fmt.Errorf("模板驗證失敗: %w", err)
+			cmd.Printf("模型 '%s' 已存在\n", item.Model)
+			item.Status = initStatusPresent
+
+			// --dry-run 只列出差異，不修改 Anki
+			if upgrade || dryRun {
+				modelUpgrade, err := planUpgrade(ctx, client, item.CardType, item.config)
+				if err != nil {
+					cmd.PrintErrf("錯誤: %v\n", err)
+					item.fail(err)
+					continue
+				}
+				printModelUpgrade(cmd.OutOrStdout(), modelUpgrade, dryRun)
+
+				item.Status = initStatusUpToDate
+				if !modelUpgrade.upToDate() {
+					item.Status = initStatusUpgrade
+					if !dryRun {
+						item.upgrade = modelUpgrade
+						item.upgradeIdx = setupBatch.Len()
+						item.upgradeStep = queueModelUpgrade(setupBatch, modelUpgrade)
+					}
+				}
 			}
-
-			// 使用簡化的 Anki 模板
-			frontTemplate := `
-<div class="card-front">
-  <div class="context-sentence">{{情境例句}}</div>
-  <div class="meaning-hint">{{核心意義}}</div>
-  {{#圖片提示}}<div class="image-hint"><img src="{{圖片提示}}"></div>{{/圖片提示}}
-</div>
-`
-			backTemplate := `
-<div class="card-back">
-  <div class="context-sentence">{{情境例句}}</div>
-  <div class="core-word">{{核心單字}}</div>
-  <div class="word-info">
-    <div class="pronunciation">{{發音}}</div>
-    <div class="accent">{{重音}}</div>
-    <div class="word-type">{{詞性分類}}</div>
-  </div>
-  <div class="translation">{{例句翻譯}}</div>
-  <div class="conjugations">{{常用變化}}</div>
-  {{#圖片提示}}<div class="image"><img src="{{圖片提示}}"></div>{{/圖片提示}}
-</div>
-`
-			// 根據卡片類型調整模板
-			if cardType == "adjective" {
-				frontTemplate = `
-<div class="card-front">
-  <div class="context-sentence">{{情境例句}}</div>
-  <div class="meaning-hint">{{核心意義}}</div>
-</div>
-`
-				backTemplate = `
-<div class="card-back">
-  <div class="context-sentence">{{情境例句}}</div>
-  <div class="core-word">{{核心單字}}</div>
-  <div class="word-info">
-    <div class="pronunciation">{{發音}}</div>
-    <div class="accent">{{重音}}</div>
-    <div class="word-type">{{詞性分類}}</div>
-  </div>
-  <div class="translation">{{例句翻譯}}</div>
-  <div class="conjugations">{{主要變化}}</div>
-  {{#相關詞彙}}<div class="related-words">{{相關詞彙}}</div>{{/相關詞彙}}
-</div>
-`
-			} else if cardType == "normal" {
-				frontTemplate = `
-<div class="card-front">
-  <div class="context-sentence">{{情境例句}}</div>
-  <div class="meaning-hint">{{核心意義}}</div>
-  {{#圖片提示}}<div class="image-hint"><img src="{{圖片提示}}"></div>{{/圖片提示}}
-</div>
-`
-				backTemplate = `
-<div class="card-back">
-  <div class="context-sentence">{{情境例句}}</div>
-  <div class="core-word">{{核心單字}}</div>
-  <div class="word-info">
-    <div class="pronunciation">{{發音}}</div>
-    <div class="accent">{{重音}}</div>
-    <div class="word-type">{{詞性}}</div>
-  </div>
-  <div class="translation">{{例句翻譯}}</div>
-  {{#相關詞彙}}<div class="related-words">{{相關詞彙}}</div>{{/相關詞彙}}
-  {{#圖片提示}}<div class="image"><img src="{{圖片提示}}"></div>{{/圖片提示}}
-</div>
-`
-			} else if cardType == "grammar" {
-				frontTemplate = `
-<div class="card-front">
-  <div class="grammar-challenge">{{情境課題}}</div>
-  <div class="grammar-point-hint">使用「{{文法點}}」</div>
-</div>
-`
-				backTemplate = `
-<div class="card-back">
-  <div class="challenge">{{情境課題}}</div>
-  <div class="answer">{{解答範例}}</div>
-  <div class="grammar-info">
-    <div class="grammar-point">{{文法點}}</div>
-    <div class="meaning">{{核心意義}}</div>
-    <div class="connection-rules">{{接續規則}}</div>
-    <div class="usage-notes">{{語感說明}}</div>
-  </div>
-  {{#易混淆文法}}<div class="confusing-grammar">{{易混淆文法}}</div>{{/易混淆文法}}
-</div>
-`
+			if !dryRun {
+				item.deckIdx = setupBatch.QueueCreateDeck(item.Deck)
 			}
+		}
 
-			// 建立模型設定
-			modelConfig := anki.ModelConfig{
-				ModelName:     modelDef.Name,
-				InOrderFields: modelDef.Fields,
-				CSS:           defaultCSS,
-				CardTemplates: []anki.CardTemplateConfig{
-					{
-						Name:  modelDef.Name,
-						Front: frontTemplate,
-						Back:  backTemplate,
-					},
-				},
+		if setupBatch.Len() > 0 {
+			setupResults, err := setupBatch.ExecuteContext(ctx)
+			if err != nil {
+				cmd.PrintErrf("錯誤: 無法建立模型與牌組: %v\n", err)
+				return fmt.Errorf("無法建立模型與牌組: %w", err)
 			}
 
-			// 建立模型
-			if err := client.CreateModel(modelConfig); err != nil {
-				cmd.PrintErrf("錯誤: 無法建立模型: %v\n", err)
-				return fmt.Errorf("無法建立模型: %w", err)
+			for _, item := range items {
+				// 建立模型
+				if item.modelIdx >= 0 {
+					if err := setupResults[item.modelIdx].Err; err != nil {
+						cmd.PrintErrf("錯誤: 無法建立模型 '%s': %v\n", item.Model, err)
+						item.fail(fmt.Errorf("無法建立模型: %w", err))
+					} else {
+						item.Status = initStatusCreated
+						cmd.Printf("✓ 成功建立模型 '%s'\n", item.Model)
+					}
+				}
+
+				// 更新模型
+				if item.upgrade != nil {
+					item.Status = initStatusUpgraded
+					for i, step := range item.upgradeStep {
+						if err := setupResults[item.upgradeIdx+i].Err; err != nil {
+							cmd.PrintErrf("錯誤: 無法更新模型 '%s': %s失敗: %v\n", item.Model, step, err)
+							item.fail(fmt.Errorf("無法更新模型: %s失敗: %w", step, err))
+							break
+						}
+					}
+					if item.Err == nil {
+						cmd.Printf("✓ 成功更新模型 '%s'\n", item.Model)
+					}
+				}
+
+				// 確保牌組存在
+				if item.deckIdx >= 0 {
+					if err := setupResults[item.deckIdx].Err; err != nil {
+						cmd.PrintErrf("錯誤: 無法建立牌組 '%s': %v\n", item.Deck, err)
+						item.DeckStatus = initStatusFailed
+						if item.Err == nil {
+							item.Err = fmt.Errorf("無法建立牌組: %w", err)
+						}
+					} else {
+						item.DeckStatus = "已就緒"
+						cmd.Printf("✓ 牌組 '%s' 已就緒\n", item.Deck)
+					}
+				}
 			}
-
-			cmd.Printf("✓ 成功建立模型 '%s'\n", modelDef.Name)
 		}
 
-		// 確保牌組存在
-		cmd.Printf("正在確保牌組 '%s' 存在...\n", modelDef.Deck)
-		if err := client.EnsureDeckExists(modelDef.Deck); err != nil {
-			cmd.PrintErrf("錯誤: 無法建立牌組: %v\n", err)
-			return fmt.Errorf("無法建立牌組: %w", err)
+		cmd.Println()
+		printInitSummary(cmd.OutOrStdout(), items)
+
+		var failed []string
+		for _, item := range items {
+			if item.Err != nil {
+				failed = append(failed, item.CardType)
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("%d 個卡片類型初始化失敗: %s", len(failed), strings.Join(failed, ", "))
+		}
+		if dryRun {
+			return nil
 		}
-		cmd.Printf("✓ 牌組 '%s' 已就緒\n", modelDef.Deck)
 
 		cmd.Println("\n初始化完成！您現在可以使用以下指令新增卡片:")
-		cmd.Printf("./anki-japanese-cli add %s --deckName='%s' --json='{...}'\n", cardType, modelDef.Deck)
+		for _, item := range items {
+			cmd.Printf("./anki-japanese-cli add %s --deckName='%s' --json='{...}'\n", item.CardType, item.Deck)
+		}
 		return nil
 	},
 }
 
+// buildModelConfig 依卡片類型的定義產生 Anki 模型設定；
+// 內建類型的模板由嵌入的 HTML 產生 (directions 中的每個方向多一個卡片模板)，
+// 自訂類型直接使用 YAML 中的模板與 CSS
+func buildModelConfig(cardType string, directions []string) (anki.ModelConfig, error) {
+	modelDef, exists := cardModel(cardType)
+	if !exists {
+		return anki.ModelConfig{}, fmt.Errorf("找不到卡片類型 '%s' 的定義", cardType)
+	}
+
+	if !modelDef.Builtin {
+		cardTemplates := []anki.CardTemplateConfig{
+			{
+				Name:  modelDef.ModelName,
+				Front: modelDef.Front,
+				Back:  modelDef.Back,
+			},
+		}
+		for _, tmpl := range modelDef.Templates {
+			cardTemplates = append(cardTemplates, anki.CardTemplateConfig{
+				Name:  tmpl.Name,
+				Front: tmpl.Front,
+				Back:  tmpl.Back,
+			})
+		}
+		return anki.ModelConfig{
+			ModelName:     modelDef.ModelName,
+			InOrderFields: modelDef.Fields,
+			CSS:           modelDef.CSS,
+			IsCloze:       modelDef.IsCloze,
+			CardTemplates: cardTemplates,
+		}, nil
+	}
+
+	// 載入模板
+	templateManager, err := templates.NewTemplateManager()
+	if err != nil {
+		return anki.ModelConfig{}, fmt.Errorf("無法初始化模板管理器: %w", err)
+	}
+
+	// 由嵌入的 HTML 模板產生 Anki 卡片模板與 CSS
+	ankiTemplate, err := templateManager.GetAnkiTemplate(cardType)
+	if err != nil {
+		return anki.ModelConfig{}, fmt.Errorf("模板轉換失敗: %w", err)
+	}
+
+	cardTemplates := []anki.CardTemplateConfig{
+		{
+			Name:  modelDef.ModelName,
+			Front: ankiTemplate.Front,
+			Back:  ankiTemplate.Back,
+		},
+	}
+	css := ankiTemplate.CSS
+
+	// 額外的卡片方向：模板名稱使用方向名稱，CSS 只加入尚未包含的方向樣式
+	for _, direction := range directions {
+		if !modelDef.SupportsDirection(direction) {
+			return anki.ModelConfig{}, fmt.Errorf("卡片類型 '%s' 不支援卡片方向 '%s'", cardType, direction)
+		}
+		directionTemplate, err := templateManager.GetDirectionTemplate(direction)
+		if err != nil {
+			return anki.ModelConfig{}, fmt.Errorf("模板轉換失敗: %w", err)
+		}
+		cardTemplates = append(cardTemplates, anki.CardTemplateConfig{
+			Name:  templates.DirectionTemplateName(direction),
+			Front: directionTemplate.Front,
+			Back:  directionTemplate.Back,
+		})
+		if directionTemplate.CSS != "" && !strings.Contains(css, directionTemplate.CSS) {
+			css += "\n" + directionTemplate.CSS
+		}
+	}
+
+	return anki.ModelConfig{
+		ModelName:     modelDef.ModelName,
+		InOrderFields: modelDef.Fields,
+		CSS:           css,
+		IsCloze:       modelDef.IsCloze,
+		CardTemplates: cardTemplates,
+	}, nil
+}
+
+// planUpgrade 取得既有模型的狀態並與模型設定比較
+func planUpgrade(ctx context.Context, client *anki.Client, cardType string, modelConfig anki.ModelConfig) (*modelUpgrade, error) {
+	fields, templates, css, err := fetchModelState(ctx, client, modelConfig.ModelName)
+	if err != nil {
+		return nil, fmt.Errorf("無法取得模型 '%s' 的狀態: %w", modelConfig.ModelName, err)
+	}
+	return planModelUpgrade(cardType, modelConfig, fields, templates, css), nil
+}
+
 func init() {
 	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().Bool("upgrade", false, "更新既有模型的欄位、模板與 CSS 為目前的定義")
+	initCmd.Flags().Bool("dry-run", false, "只列出既有模型與目前定義的差異，不修改 Anki")
+	initCmd.Flags().StringSlice("directions", nil, "額外產生的卡片方向 (production,reading,listening；none 表示不產生)")
 }
@@ -8,6 +8,7 @@ import (
 
 	"anki-japanese-cli/internal/anki"
 	"anki-japanese-cli/internal/config"
+	"anki-japanese-cli/internal/models"
 )
 
 // Ensure imports are used
@@ -238,3 +239,98 @@ func TestInitCommandUnit(t *testing.T) {
 		})
 	}
 }
+
+// TestParseInitArgs tests expanding "all" and lists of card types
+func TestParseInitArgs(t *testing.T) {
+	factory := models.NewCardFactory()
+
+	cardTypes, err := parseInitArgs(factory, []string{"all"})
+	if err != nil {
+		t.Fatalf("parseInitArgs(all) error = %v", err)
+	}
+	if strings.Join(cardTypes, ",") != strings.Join(factory.GetSupportedCardTypes(), ",") {
+		t.Errorf("parseInitArgs(all) = %v", cardTypes)
+	}
+
+	cardTypes, err = parseInitArgs(factory, []string{"Verb", "grammar", "verb"})
+	if err != nil {
+		t.Fatalf("parseInitArgs() error = %v", err)
+	}
+	if strings.Join(cardTypes, ",") != "verb,grammar" {
+		t.Errorf("parseInitArgs() = %v, expected [verb grammar]", cardTypes)
+	}
+
+	if _, err := parseInitArgs(factory, []string{"verb", "invalid"}); err == nil {
+		t.Error("parseInitArgs() expected an error for an unsupported card type")
+	}
+}
+
+// TestPrintInitSummary tests the summary table of init
+func TestPrintInitSummary(t *testing.T) {
+	items := []*initItem{
+		{CardType: "verb", Model: "Japanese Verb", Deck: "日文動詞", Status: initStatusCreated, DeckStatus: "已就緒"},
+		{CardType: "grammar", Model: "Japanese Grammar", Deck: "日文文法", Status: initStatusUpgrade},
+	}
+	items[1].fail(errors.New("boom"))
+
+	var buf bytes.Buffer
+	printInitSummary(&buf, items)
+	output := buf.String()
+
+	for _, expected := range []string{"類型", "Japanese Verb", "已建立", "已就緒", "失敗: boom"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("printInitSummary() output missing %q:\n%s", expected, output)
+		}
+	}
+	if lines := strings.Split(strings.TrimSpace(output), "\n"); len(lines) != 3 {
+		t.Errorf("printInitSummary() printed %d lines, expected 3", len(lines))
+	}
+}
+
+// TestResolveDirections tests normalising and filtering the requested card directions
+func TestResolveDirections(t *testing.T) {
+	verb, _ := cardModel("verb")
+	directions, skipped, err := resolveDirections(verb, []string{"Reading", " production", "reading", "none"})
+	if err != nil {
+		t.Fatalf("resolveDirections() error = %v", err)
+	}
+	if strings.Join(directions, ",") != "reading,production" || len(skipped) != 0 {
+		t.Errorf("resolveDirections() = %v, %v, expected [reading production]", directions, skipped)
+	}
+
+	grammar, _ := cardModel("grammar")
+	directions, skipped, err = resolveDirections(grammar, []string{"listening"})
+	if err != nil || len(directions) != 0 || strings.Join(skipped, ",") != "listening" {
+		t.Errorf("resolveDirections(grammar) = %v, %v, %v, expected listening to be skipped", directions, skipped, err)
+	}
+
+	if _, _, err := resolveDirections(verb, []string{"writing"}); err == nil {
+		t.Error("resolveDirections() expected an error for an unknown direction")
+	}
+}
+
+// TestBuildModelConfigDirections tests that each direction adds a card template to the model
+func TestBuildModelConfigDirections(t *testing.T) {
+	modelConfig, err := buildModelConfig("verb", []string{"production", "listening"})
+	if err != nil {
+		t.Fatalf("buildModelConfig() error = %v", err)
+	}
+
+	var names []string
+	for _, tmpl := range modelConfig.CardTemplates {
+		names = append(names, tmpl.Name)
+	}
+	if strings.Join(names, ",") != "Japanese Verb,中→日,聽力" {
+		t.Errorf("card templates = %v, expected [Japanese Verb 中→日 聽力]", names)
+	}
+	if !strings.Contains(modelConfig.CardTemplates[2].Front, "{{#音檔}}") {
+		t.Errorf("listening front should only render with audio:\n%s", modelConfig.CardTemplates[2].Front)
+	}
+	if !strings.Contains(modelConfig.CSS, ".direction-prompt") {
+		t.Error("expected the direction CSS to be added to the model")
+	}
+
+	if _, err := buildModelConfig("grammar", []string{"reading"}); err == nil {
+		t.Error("buildModelConfig() expected an error for an unsupported direction")
+	}
+}
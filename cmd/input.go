@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 卡片資料檔案的格式 (依副檔名判斷，或以 --format 指定)
+const (
+	inputFormatJSON   = "json"
+	inputFormatNDJSON = "ndjson"
+	inputFormatYAML   = "yaml"
+	inputFormatCSV    = "csv"
+	inputFormatTSV    = "tsv"
+)
+
+// stdinPath 以 --file - 從標準輸入讀取卡片資料
+const stdinPath = "-"
+
+// validateInputFormat 檢查 --format 的值
+func validateInputFormat(format string) error {
+	switch format {
+	case inputFormatJSON, inputFormatNDJSON, inputFormatYAML, inputFormatCSV, inputFormatTSV:
+		return nil
+	}
+	return fmt.Errorf("不支援的檔案格式: %s (可用: json, ndjson, yaml, csv, tsv)", format)
+}
+
+// detectInputFormat 依副檔名判斷卡片資料檔案的格式；
+// 標準輸入 (-) 視為 NDJSON，無法判斷時視為 JSON
+func detectInputFormat(path string) string {
+	if path == stdinPath {
+		return inputFormatNDJSON
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ndjson", ".jsonl":
+		return inputFormatNDJSON
+	case ".yaml", ".yml":
+		return inputFormatYAML
+	case ".csv":
+		return inputFormatCSV
+	case ".tsv", ".tab":
+		return inputFormatTSV
+	}
+	return inputFormatJSON
+}
+
+// cardSource 逐筆讀取卡片資料，大型檔案不需要一次載入記憶體
+type cardSource interface {
+	// Next 回傳下一張卡片的資料，沒有更多資料時回傳 io.EOF
+	Next() (map[string]interface{}, error)
+}
+
+// openCardSource 開啟卡片資料檔案；回傳的關閉函式必須在讀取完成後呼叫
+func openCardSource(path, format string, batch bool, fieldMap map[string]string) (cardSource, func() error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("無法讀取檔案: %w", err)
+	}
+
+	source, err := newCardSource(file, format, batch, fieldMap)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return source, file.Close, nil
+}
+
+// spoolStdin 將標準輸入的卡片資料暫存到檔案，讓驗證與新增可以各讀取一次；
+// 回傳的清除函式會刪除暫存檔案
+func spoolStdin(stdin io.Reader) (string, func(), error) {
+	file, err := os.CreateTemp("", "anki-japanese-cli-*.input")
+	if err != nil {
+		return "", nil, fmt.Errorf("無法暫存標準輸入: %w", err)
+	}
+	cleanup := func() { os.Remove(file.Name()) }
+	if _, err := io.Copy(file, stdin); err != nil {
+		file.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("無法讀取標準輸入: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("無法暫存標準輸入: %w", err)
+	}
+	return file.Name(), cleanup, nil
+}
+
+// newCardSource 建立指定格式的卡片資料來源；
+// JSON 在批次模式下為陣列，否則為單一物件
+func newCardSource(r io.Reader, format string, batch bool, fieldMap map[string]string) (cardSource, error) {
+	switch format {
+	case inputFormatJSON:
+		return &jsonSource{decoder: json.NewDecoder(r), batch: batch}, nil
+	case inputFormatNDJSON:
+		return &ndjsonSource{decoder: json.NewDecoder(r)}, nil
+	case inputFormatYAML:
+		return &yamlSource{decoder: yaml.NewDecoder(r)}, nil
+	case inputFormatCSV, inputFormatTSV:
+		source, err := newDelimitedSource(r, format, fieldMap)
+		if err != nil {
+			return nil, err
+		}
+		return source, nil
+	}
+	return nil, validateInputFormat(format)
+}
+
+// sliceSource 已在記憶體中的卡片資料 (--json)
+type sliceSource struct {
+	cards []map[string]interface{}
+}
+
+// Next 依序回傳卡片資料
+func (s *sliceSource) Next() (map[string]interface{}, error) {
+	if len(s.cards) == 0 {
+		return nil, io.EOF
+	}
+	card := s.cards[0]
+	s.cards = s.cards[1:]
+	return card, nil
+}
+
+// jsonSource JSON 檔案：單一物件，或批次模式下的物件陣列 (逐一解碼陣列中的元素)
+type jsonSource struct {
+	decoder *json.Decoder
+	batch   bool
+	started bool
+	done    bool
+}
+
+// Next 回傳下一張卡片的資料
+func (s *jsonSource) Next() (map[string]interface{}, error) {
+	if s.done {
+		return nil, io.EOF
+	}
+
+	if !s.batch {
+		s.done = true
+		var card map[string]interface{}
+		if err := s.decoder.Decode(&card); err != nil {
+			return nil, fmt.Errorf("JSON 解析失敗: %w", err)
+		}
+		return card, nil
+	}
+
+	if !s.started {
+		s.started = true
+		token, err := s.decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("JSON 解析失敗: %w", err)
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("JSON 解析失敗: 批次模式的檔案必須是陣列")
+		}
+	}
+	if !s.decoder.More() {
+		s.done = true
+		if _, err := s.decoder.Token(); err != nil {
+			return nil, fmt.Errorf("JSON 解析失敗: %w", err)
+		}
+		return nil, io.EOF
+	}
+	var card map[string]interface{}
+	if err := s.decoder.Decode(&card); err != nil {
+		return nil, fmt.Errorf("JSON 解析失敗: %w", err)
+	}
+	return card, nil
+}
+
+// ndjsonSource 每行一個 JSON 物件 (NDJSON/JSON Lines)，空白行會被略過
+type ndjsonSource struct {
+	decoder *json.Decoder
+	count   int
+}
+
+// Next 回傳下一張卡片的資料
+func (s *ndjsonSource) Next() (map[string]interface{}, error) {
+	for {
+		var card map[string]interface{}
+		err := s.decoder.Decode(&card)
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		s.count++
+		if err != nil {
+			return nil, fmt.Errorf("NDJSON 第 %d 筆資料解析失敗: %w", s.count, err)
+		}
+		if card != nil {
+			return card, nil
+		}
+	}
+}
+
+// yamlSource YAML 檔案：每個文件 (以 --- 分隔) 為一張卡片，或為卡片的清單
+type yamlSource struct {
+	decoder *yaml.Decoder
+	pending []*yaml.Node // 目前清單中尚未讀取的卡片
+	count   int
+}
+
+// Next 回傳下一張卡片的資料
+func (s *yamlSource) Next() (map[string]interface{}, error) {
+	for len(s.pending) == 0 {
+		var doc yaml.Node
+		if err := s.decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("YAML 解析失敗: %w", err)
+		}
+		if len(doc.Content) == 0 {
+			continue
+		}
+		switch node := doc.Content[0]; node.Kind {
+		case yaml.SequenceNode:
+			s.pending = node.Content
+		case yaml.ScalarNode:
+			if node.Tag != "!!null" {
+				return nil, fmt.Errorf("YAML 解析失敗: 文件必須是卡片或卡片的清單")
+			}
+		default:
+			s.pending = []*yaml.Node{node}
+		}
+	}
+
+	node := s.pending[0]
+	s.pending = s.pending[1:]
+	s.count++
+	card, err := yamlCard(node)
+	if err != nil {
+		return nil, fmt.Errorf("YAML 第 %d 張卡片 (第 %d 行): %w", s.count, node.Line, err)
+	}
+	return card, nil
+}
+
+// yamlCard 將 YAML 對應轉換為卡片資料；欄位值一律視為文字 (重音: 1 → "1")，空值會被略過
+func yamlCard(node *yaml.Node) (map[string]interface{}, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("卡片必須是欄位的對應 (欄位: 值)")
+	}
+	card := make(map[string]interface{}, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("欄位 '%s' 必須是文字", key)
+		}
+		if value.Tag == "!!null" {
+			continue
+		}
+		card[key] = value.Value
+	}
+	return card, nil
+}
+
+// delimitedSource CSV 或 TSV 檔案：第一列為標題，其後每一列為一張卡片
+type delimitedSource struct {
+	reader *csv.Reader
+	format string
+	fields []string // 每一欄對應的卡片欄位
+}
+
+// newDelimitedSource 讀取標題列並建立 CSV/TSV 資料來源；
+// 標題以 fieldMap 對應到卡片欄位 (未對應的標題直接作為欄位名稱)
+func newDelimitedSource(r io.Reader, format string, fieldMap map[string]string) (*delimitedSource, error) {
+	reader := csv.NewReader(r)
+	if format == inputFormatTSV {
+		reader.Comma = '\t'
+		reader.LazyQuotes = true
+	}
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("檔案沒有標題列")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s 解析失敗: %w", strings.ToUpper(format), err)
+	}
+
+	fields, err := mapHeader(header, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	return &delimitedSource{reader: reader, format: format, fields: fields}, nil
+}
+
+// Next 回傳下一列的卡片資料；空白的儲存格不會加入卡片資料，全部空白的列會被略過
+func (s *delimitedSource) Next() (map[string]interface{}, error) {
+	for {
+		record, err := s.reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s 解析失敗: %w", strings.ToUpper(s.format), err)
+		}
+
+		data := make(map[string]interface{}, len(s.fields))
+		for i, value := range record {
+			if value = strings.TrimSpace(value); value != "" {
+				data[s.fields[i]] = value
+			}
+		}
+		if len(data) > 0 {
+			return data, nil
+		}
+	}
+}
+
+// parseFieldMap 解析 --map 的標題對應 ("word=核心單字,reading=發音")
+func parseFieldMap(spec string) (map[string]string, error) {
+	fieldMap := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		header, field, ok := strings.Cut(pair, "=")
+		header, field = strings.TrimSpace(header), strings.TrimSpace(field)
+		if !ok || header == "" || field == "" {
+			return nil, fmt.Errorf("無效的欄位對應: %s (格式為 標題=欄位)", pair)
+		}
+		if _, exists := fieldMap[header]; exists {
+			return nil, fmt.Errorf("標題 '%s' 重複對應", header)
+		}
+		fieldMap[header] = field
+	}
+	return fieldMap, nil
+}
+
+// mapHeader 將標題列對應到卡片欄位名稱；--map 中不存在於標題列的標題與重複的欄位會回傳錯誤
+func mapHeader(header []string, fieldMap map[string]string) ([]string, error) {
+	fields := make([]string, len(header))
+	columns := make(map[string]int, len(header))
+	used := make(map[string]bool, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if name == "" {
+			return nil, fmt.Errorf("第 %d 欄的標題為空", i+1)
+		}
+		columns[name] = i
+
+		field := name
+		if mapped, ok := fieldMap[name]; ok {
+			field = mapped
+		}
+		if used[field] {
+			return nil, fmt.Errorf("欄位 '%s' 在標題列中重複", field)
+		}
+		used[field] = true
+		fields[i] = field
+	}
+
+	var missing []string
+	for name := range fieldMap {
+		if _, ok := columns[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("--map 的標題不在標題列中: %s", strings.Join(missing, ", "))
+	}
+	return fields, nil
+}
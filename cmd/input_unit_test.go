@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readAllCards drains a card source, returning the cards read before the first error
+func readAllCards(source cardSource) ([]map[string]interface{}, error) {
+	var cards []map[string]interface{}
+	for {
+		card, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			return cards, nil
+		}
+		if err != nil {
+			return cards, err
+		}
+		cards = append(cards, card)
+	}
+}
+
+// readCards opens a card source over input and drains it
+func readCards(input, format string, batch bool, fieldMap map[string]string) ([]map[string]interface{}, error) {
+	source, err := newCardSource(strings.NewReader(input), format, batch, fieldMap)
+	if err != nil {
+		return nil, err
+	}
+	return readAllCards(source)
+}
+
+// TestDetectInputFormat tests choosing the input format from the file extension
+func TestDetectInputFormat(t *testing.T) {
+	tests := map[string]string{
+		"cards.json":   inputFormatJSON,
+		"cards.CSV":    inputFormatCSV,
+		"cards.tsv":    inputFormatTSV,
+		"cards.tab":    inputFormatTSV,
+		"cards":        inputFormatJSON,
+		"data.v2.csv":  inputFormatCSV,
+		"cards.ndjson": inputFormatNDJSON,
+		"cards.jsonl":  inputFormatNDJSON,
+		"cards.yaml":   inputFormatYAML,
+		"cards.YML":    inputFormatYAML,
+		"-":            inputFormatNDJSON,
+	}
+	for path, expected := range tests {
+		if got := detectInputFormat(path); got != expected {
+			t.Errorf("detectInputFormat(%s) = %s, expected %s", path, got, expected)
+		}
+	}
+
+	if err := validateInputFormat("xml"); err == nil {
+		t.Error("validateInputFormat(xml) expected an error")
+	}
+}
+
+// TestParseFieldMap tests parsing --map into header → field pairs
+func TestParseFieldMap(t *testing.T) {
+	fieldMap, err := parseFieldMap("word=核心單字, reading = 發音,")
+	if err != nil {
+		t.Fatalf("parseFieldMap() error = %v", err)
+	}
+	if len(fieldMap) != 2 || fieldMap["word"] != "核心單字" || fieldMap["reading"] != "發音" {
+		t.Errorf("parseFieldMap() = %v", fieldMap)
+	}
+
+	for _, spec := range []string{"word", "word=", "=核心單字", "word=核心單字,word=發音"} {
+		if _, err := parseFieldMap(spec); err == nil {
+			t.Errorf("parseFieldMap(%q) expected an error", spec)
+		}
+	}
+}
+
+// TestReadDelimited tests reading CSV and TSV rows with header mapping
+func TestReadDelimited(t *testing.T) {
+	csvInput := "\ufeffword,發音,核心意義\n猫,ねこ,\"貓, 貓咪\"\n犬,いぬ,\n\n"
+	cardData, err := readCards(csvInput, inputFormatCSV, true, map[string]string{"word": "核心單字"})
+	if err != nil {
+		t.Fatalf("readCards() error = %v", err)
+	}
+	if len(cardData) != 2 {
+		t.Fatalf("readCards() returned %d cards, expected 2", len(cardData))
+	}
+	if cardData[0]["核心單字"] != "猫" || cardData[0]["核心意義"] != "貓, 貓咪" {
+		t.Errorf("readCards()[0] = %v", cardData[0])
+	}
+	if _, ok := cardData[1]["核心意義"]; ok {
+		t.Errorf("readCards()[1] should omit empty cells, got %v", cardData[1])
+	}
+
+	tsvInput := "核心單字\t使用方式\n猫\t量詞用 \"匹\"\n"
+	cardData, err = readCards(tsvInput, inputFormatTSV, true, nil)
+	if err != nil {
+		t.Fatalf("readCards() TSV error = %v", err)
+	}
+	if len(cardData) != 1 || cardData[0]["使用方式"] != `量詞用 "匹"` {
+		t.Errorf("readCards() TSV = %v", cardData)
+	}
+}
+
+// TestReadDelimitedErrors tests header problems and ragged rows
+func TestReadDelimitedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		fieldMap map[string]string
+	}{
+		{"empty file", "", nil},
+		{"missing mapped header", "核心單字,發音\n猫,ねこ\n", map[string]string{"word": "核心單字"}},
+		{"duplicate field", "word,核心單字\n猫,猫\n", map[string]string{"word": "核心單字"}},
+		{"empty header", "核心單字,,發音\n猫,x,ねこ\n", nil},
+		{"ragged row", "核心單字,發音\n猫\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := readCards(tt.input, inputFormatCSV, true, tt.fieldMap); err == nil {
+				t.Error("readCards() expected an error")
+			}
+		})
+	}
+}
+
+// TestReadJSON tests streaming a JSON array in batch mode and a single object otherwise
+func TestReadJSON(t *testing.T) {
+	cardData, err := readCards(`[{"核心單字":"猫"}, {"核心單字":"犬","重音":1}]`, inputFormatJSON, true, nil)
+	if err != nil {
+		t.Fatalf("readCards() error = %v", err)
+	}
+	if len(cardData) != 2 || cardData[0]["核心單字"] != "猫" || cardData[1]["核心單字"] != "犬" {
+		t.Errorf("readCards() = %v", cardData)
+	}
+
+	cardData, err = readCards(`{"核心單字":"猫"}`, inputFormatJSON, false, nil)
+	if err != nil || len(cardData) != 1 || cardData[0]["核心單字"] != "猫" {
+		t.Errorf("readCards() single = %v, %v", cardData, err)
+	}
+
+	if _, err := readCards(`{"核心單字":"猫"}`, inputFormatJSON, true, nil); err == nil {
+		t.Error("readCards() batch mode expected an error for a non-array file")
+	}
+	cardData, err = readCards(`[{"核心單字":"猫"}, {"核心單字":]`, inputFormatJSON, true, nil)
+	if err == nil || len(cardData) != 1 {
+		t.Errorf("readCards() truncated array = %v, %v; expected one card and an error", cardData, err)
+	}
+}
+
+// TestReadNDJSON tests reading one card per line, skipping blank lines
+func TestReadNDJSON(t *testing.T) {
+	input := "{\"核心單字\":\"猫\"}\n\n  \n{\"核心單字\":\"犬\"}\r\n{\"核心單字\":\"鳥\"}"
+	cardData, err := readCards(input, inputFormatNDJSON, false, nil)
+	if err != nil {
+		t.Fatalf("readCards() error = %v", err)
+	}
+	if len(cardData) != 3 || cardData[2]["核心單字"] != "鳥" {
+		t.Errorf("readCards() = %v", cardData)
+	}
+
+	cardData, err = readCards("{\"核心單字\":\"猫\"}\n{\"核心單字\": 猫}\n", inputFormatNDJSON, false, nil)
+	if err == nil || !strings.Contains(err.Error(), "第 2 筆") {
+		t.Errorf("readCards() error = %v, expected an error for record 2", err)
+	}
+	if len(cardData) != 1 {
+		t.Errorf("readCards() returned %d cards before the error, expected 1", len(cardData))
+	}
+}
+
+// TestReadYAML tests multi-document YAML where each document is a card or a list of cards
+func TestReadYAML(t *testing.T) {
+	input := `核心單字: 猫
+重音: 1
+---
+- 核心單字: 犬
+  發音: いぬ
+  核心意義:
+- 核心單字: 鳥
+---
+`
+	cardData, err := readCards(input, inputFormatYAML, true, nil)
+	if err != nil {
+		t.Fatalf("readCards() error = %v", err)
+	}
+	if len(cardData) != 3 {
+		t.Fatalf("readCards() returned %d cards, expected 3", len(cardData))
+	}
+	if cardData[0]["重音"] != "1" {
+		t.Errorf("readCards()[0][重音] = %#v, expected \"1\"", cardData[0]["重音"])
+	}
+	if cardData[1]["發音"] != "いぬ" {
+		t.Errorf("readCards()[1] = %v", cardData[1])
+	}
+	if _, ok := cardData[1]["核心意義"]; ok {
+		t.Errorf("readCards()[1] should omit null fields, got %v", cardData[1])
+	}
+
+	for name, input := range map[string]string{
+		"nested value": "核心單字: 猫\n常用變化: [a, b]\n",
+		"scalar card":  "- 猫\n",
+		"invalid yaml": "核心單字: [猫\n",
+		"scalar doc":   "猫\n",
+	} {
+		if _, err := readCards(input, inputFormatYAML, true, nil); err == nil {
+			t.Errorf("readCards() %s expected an error", name)
+		}
+	}
+}
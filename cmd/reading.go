@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"anki-japanese-cli/internal/japanese"
+)
+
+// normalizeReading 正規化卡片資料的發音：統一全形/半形與 Unicode 形式，並將羅馬拼音轉換為平假名。
+// 有修改時回傳更新後的卡片資料 (不修改原本的 map)；
+// 無法轉換的羅馬拼音保持原樣，由卡片驗證回報錯誤
+func normalizeReading(data map[string]interface{}) (map[string]interface{}, bool) {
+	reading, ok := data["發音"].(string)
+	if !ok || reading == "" {
+		return data, false
+	}
+	normalized, err := japanese.NormalizeReading(reading)
+	if err != nil || normalized == reading {
+		return data, false
+	}
+	return withField(data, "發音", normalized), true
+}
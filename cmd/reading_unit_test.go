@@ -0,0 +1,38 @@
+package cmd
+
+import "testing"
+
+// TestNormalizeReading tests converting romaji and half-width kana in 發音 before validation
+func TestNormalizeReading(t *testing.T) {
+	tests := []struct {
+		name     string
+		reading  interface{}
+		expected interface{}
+		changed  bool
+	}{
+		{"romaji", "taberu", "たべる", true},
+		{"half-width katakana", "ﾀﾍﾞﾙ", "タベル", true},
+		{"already kana", "たべる", "たべる", false},
+		{"invalid romaji kept", "xyz", "xyz", false},
+		{"missing", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]interface{}{"核心單字": "食べる"}
+			if tt.reading != nil {
+				data["發音"] = tt.reading
+			}
+			normalized, changed := normalizeReading(data)
+			if changed != tt.changed {
+				t.Errorf("normalizeReading() changed = %v, expected %v", changed, tt.changed)
+			}
+			if normalized["發音"] != tt.expected {
+				t.Errorf("發音 = %v, expected %v", normalized["發音"], tt.expected)
+			}
+			if tt.changed && data["發音"] != tt.reading {
+				t.Error("normalizeReading() modified the original data")
+			}
+		})
+	}
+}
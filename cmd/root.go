@@ -1,14 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+
+	"anki-japanese-cli/internal/config"
+	"anki-japanese-cli/internal/models"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var cfgFile string
+var (
+	cfgFile string
+	// cardTypesLoaded reports whether loadCardTypes already ran
+	cardTypesLoaded bool
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -22,12 +32,19 @@ var rootCmd = &cobra.Command{
 - 批次匯入詞彙
 - 與 Anki Connect 整合`,
 	Version: "1.0.0",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadCardTypes()
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The command context is cancelled on Ctrl-C (SIGINT) or SIGTERM so that
+// in-flight Anki Connect requests abort cleanly.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
 	if err != nil {
 		os.Exit(1)
 	}
@@ -47,6 +64,24 @@ func init() {
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
+// loadCardTypes registers the user-defined card types found in the card types
+// directory. It runs once, before any command, so every command sees the same types.
+func loadCardTypes() error {
+	if cardTypesLoaded {
+		return nil
+	}
+
+	dir, err := config.CardTypesDir()
+	if err != nil {
+		return err
+	}
+	if err := models.DefaultRegistry().LoadDir(dir); err != nil {
+		return fmt.Errorf("無法載入自訂卡片類型: %w", err)
+	}
+	cardTypesLoaded = true
+	return nil
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	if cfgFile != "" {
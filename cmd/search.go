@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/config"
+	"anki-japanese-cli/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// searchResult 搜尋結果中的單一筆記
+type searchResult struct {
+	NoteID    int64                  `json:"noteId"`
+	CardType  string                 `json:"cardType,omitempty"`
+	ModelName string                 `json:"modelName"`
+	Tags      []string               `json:"tags"`
+	Fields    map[string]interface{} `json:"fields"`
+}
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "搜尋 Anki 中的日文卡片",
+	Long: `使用 Anki 搜尋語法搜尋筆記，並將結果解碼為對應的卡片類型。
+
+查詢語法與 Anki 瀏覽器相同，例如:
+- deck:日文動詞
+- tag:N3
+- 核心單字:飲む
+
+範例:
+  anki-japanese-cli search 'deck:日文動詞 tag:N3'
+  anki-japanese-cli search --type=grammar 'tag:N3' --output=json
+  anki-japanese-cli search 'deck:日文單字' --limit=20`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		query := strings.Join(args, " ")
+
+		// 取得選項
+		cardType, _ := cmd.Flags().GetString("type")
+		output, _ := cmd.Flags().GetString("output")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		if output != "table" && output != "json" {
+			cmd.PrintErrf("錯誤: 不支援的輸出格式: %s (可用: table, json)\n", output)
+			return fmt.Errorf("不支援的輸出格式: %s", output)
+		}
+
+		// 限定卡片類型
+		factory := models.NewCardFactory()
+		if cardType != "" {
+			cardType = strings.ToLower(cardType)
+			if err := factory.ValidateCardType(cardType); err != nil {
+				cmd.PrintErrf("錯誤: %v\n", err)
+				return err
+			}
+			query = anki.NoteTypeQuery(cardModelName(cardType)) + " " + query
+		}
+
+		// 載入設定
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			cmd.PrintErrf("錯誤: 無法載入設定: %v\n", err)
+			return fmt.Errorf("無法載入設定: %w", err)
+		}
+
+		// 建立 Anki 客戶端
+		client := anki.NewClient(&cfg.Anki)
+
+		// 搜尋筆記
+		noteIDs, err := client.FindNotesContext(ctx, query)
+		if err != nil {
+			cmd.PrintErrf("錯誤: 搜尋失敗: %s\n", anki.FormatError(err))
+			return fmt.Errorf("搜尋失敗: %w", err)
+		}
+
+		total := len(noteIDs)
+		if limit > 0 && len(noteIDs) > limit {
+			noteIDs = noteIDs[:limit]
+		}
+
+		notes, err := client.NotesInfoContext(ctx, noteIDs)
+		if err != nil {
+			cmd.PrintErrf("錯誤: 無法取得筆記內容: %s\n", anki.FormatError(err))
+			return fmt.Errorf("無法取得筆記內容: %w", err)
+		}
+
+		// 解碼為卡片
+		results := make([]searchResult, 0, len(notes))
+		for _, note := range notes {
+			results = append(results, decodeNote(factory, note))
+		}
+
+		out := cmd.OutOrStdout()
+		if output == "json" {
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(results)
+		}
+
+		if len(results) == 0 {
+			fmt.Fprintln(out, "找不到符合條件的卡片")
+			return nil
+		}
+
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "ID\t類型\t單字/文法\t意義\t標籤")
+		for _, result := range results {
+			keyField, meaningField := cardKeyField(result.CardType), "核心意義"
+			if result.CardType == "grammar" {
+				meaningField = "意義說明"
+			}
+			fmt.Fprintf(writer, "%d\t%s\t%v\t%v\t%s\n",
+				result.NoteID,
+				result.CardType,
+				result.Fields[keyField],
+				result.Fields[meaningField],
+				strings.Join(result.Tags, " "),
+			)
+		}
+		writer.Flush()
+
+		fmt.Fprintf(out, "\n共 %d 筆結果", total)
+		if len(results) < total {
+			fmt.Fprintf(out, " (顯示前 %d 筆)", len(results))
+		}
+		fmt.Fprintln(out)
+		return nil
+	},
+}
+
+// cardTypeForModel 根據 Anki 模型名稱找出對應的卡片類型
+func cardTypeForModel(modelName string) (string, bool) {
+	def, ok := models.DefaultRegistry().ForModel(modelName)
+	if !ok {
+		return "", false
+	}
+	return def.Name, true
+}
+
+// decodeNote 將 Anki 筆記解碼為卡片資料
+func decodeNote(factory *models.CardFactory, note anki.Note) searchResult {
+	fields := make(map[string]interface{}, len(note.Fields))
+	for name, value := range note.FieldValues() {
+		fields[name] = value
+	}
+
+	result := searchResult{
+		NoteID:    note.NoteID,
+		ModelName: note.ModelName,
+		Tags:      note.Tags,
+		Fields:    fields,
+	}
+
+	// 非本工具建立的模型保留原始欄位
+	cardType, ok := cardTypeForModel(note.ModelName)
+	if !ok {
+		return result
+	}
+
+	// 舊版 init 建立的模型先將舊版欄位名稱對應回卡片欄位名稱
+	cardFields := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		cardFields[cardFieldName(cardType, name)] = value
+	}
+
+	card, err := factory.DecodeCard(cardType, cardFields)
+	if err != nil {
+		return result
+	}
+
+	result.CardType = cardType
+	if cardData, ok := card.(models.CardData); ok {
+		result.Fields = cardData.ToMap()
+	}
+	return result
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+
+	// 定義 flags
+	searchCmd.Flags().StringP("type", "t", "", "限定卡片類型 (verb, adjective, normal, grammar, kanji)")
+	searchCmd.Flags().StringP("output", "o", "table", "輸出格式 (table, json)")
+	searchCmd.Flags().Int("limit", 0, "最多顯示的筆數 (0 表示不限制)")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"testing"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/models"
+)
+
+// TestCardTypeForModel tests the mapping from Anki model names to card types
+func TestCardTypeForModel(t *testing.T) {
+	for _, cardType := range models.DefaultRegistry().Names() {
+		modelDef, _ := cardModel(cardType)
+		got, ok := cardTypeForModel(modelDef.ModelName)
+		if !ok || got != cardType {
+			t.Errorf("cardTypeForModel(%q) = %q, %v; expected %q", modelDef.ModelName, got, ok, cardType)
+		}
+	}
+
+	if _, ok := cardTypeForModel("Basic"); ok {
+		t.Error("cardTypeForModel(Basic) should not match any card type")
+	}
+}
+
+// TestDecodeNote tests decoding Anki notes back into card data
+func TestDecodeNote(t *testing.T) {
+	factory := models.NewCardFactory()
+
+	note := anki.Note{
+		NoteID:    1502298033753,
+		ModelName: "Japanese Verb",
+		Tags:      []string{"anki-japanese-cli", "verb"},
+		Fields: map[string]anki.NoteField{
+			"核心單字": {Value: "飲む", Order: 0},
+			"核心意義": {Value: "喝", Order: 2},
+		},
+	}
+
+	result := decodeNote(factory, note)
+	if result.CardType != "verb" {
+		t.Errorf("decodeNote() card type = %q, expected verb", result.CardType)
+	}
+	if result.Fields["核心單字"] != "飲む" || result.Fields["核心意義"] != "喝" {
+		t.Errorf("decodeNote() fields = %v", result.Fields)
+	}
+	// Decoded cards expose every field of the card type
+	if _, ok := result.Fields["常用變化"]; !ok {
+		t.Errorf("decodeNote() fields should include all verb card fields, got %v", result.Fields)
+	}
+
+	// Notes of unknown models keep their raw fields
+	note.ModelName = "Basic"
+	note.Fields = map[string]anki.NoteField{"Front": {Value: "front"}}
+	result = decodeNote(factory, note)
+	if result.CardType != "" || result.Fields["Front"] != "front" {
+		t.Errorf("decodeNote() for unknown model = %+v", result)
+	}
+}
+
+// TestDecodeNoteLegacyModel tests decoding notes whose models still use the legacy field names
+func TestDecodeNoteLegacyModel(t *testing.T) {
+	factory := models.NewCardFactory()
+
+	grammar := decodeNote(factory, anki.Note{
+		NoteID:    1502298033754,
+		ModelName: "Japanese Grammar",
+		Fields: map[string]anki.NoteField{
+			"文法點":   {Value: "〜てしまう"},
+			"接續規則":  {Value: "動詞て形 + しまう"},
+			"核心意義":  {Value: "表示完成或遺憾"},
+			"語感說明":  {Value: "用於不小心或遺憾的情況"},
+			"易混淆文法": {Value: "〜ちゃう"},
+		},
+	})
+	if grammar.CardType != "grammar" {
+		t.Fatalf("decodeNote() card type = %q, expected grammar", grammar.CardType)
+	}
+	want := map[string]string{
+		"文法要點": "〜てしまう",
+		"結構形式": "動詞て形 + しまう",
+		"意義說明": "表示完成或遺憾",
+		"使用時機": "用於不小心或遺憾的情況",
+		"相關文法": "〜ちゃう",
+	}
+	for field, value := range want {
+		if grammar.Fields[field] != value {
+			t.Errorf("decodeNote() %s = %v, expected %q", field, grammar.Fields[field], value)
+		}
+	}
+
+	normal := decodeNote(factory, anki.Note{
+		NoteID:    1502298033755,
+		ModelName: cardModelName("normal"),
+		Fields: map[string]anki.NoteField{
+			"核心單字": {Value: "単語"},
+			"詞性":   {Value: "名詞"},
+			"核心意義": {Value: "單字"},
+		},
+	})
+	if normal.Fields["核心單字"] != "単語" || normal.Fields["詞性分類"] != "名詞" || normal.Fields["核心意義"] != "單字" {
+		t.Errorf("decodeNote() for legacy normal model = %v", normal.Fields)
+	}
+}
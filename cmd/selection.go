@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/furigana"
+	"anki-japanese-cli/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// addNoteSelectionFlags 定義以搜尋語法或單字檔案選取筆記的 flags
+func addNoteSelectionFlags(c *cobra.Command) {
+	c.Flags().String("query", "", "Anki 搜尋語法 (例如 'deck:日文動詞 tag:N3')")
+	c.Flags().StringP("file", "f", "", "包含單字的 JSON 檔案 (字串陣列，或與 add 相同格式的卡片陣列)")
+	c.Flags().StringP("type", "t", "", "限定卡片類型 (verb, adjective, normal, grammar, kanji)")
+}
+
+// selectNotes 依據參數中的筆記 ID、--query 或 --file 選取筆記
+func selectNotes(ctx context.Context, client *anki.Client, c *cobra.Command, args []string) ([]anki.Note, error) {
+	query, _ := c.Flags().GetString("query")
+	filePath, _ := c.Flags().GetString("file")
+	cardType, _ := c.Flags().GetString("type")
+	cardType = strings.ToLower(cardType)
+
+	if cardType != "" {
+		if err := models.NewCardFactory().ValidateCardType(cardType); err != nil {
+			return nil, err
+		}
+	}
+
+	var noteIDs []int64
+
+	// 筆記 ID
+	for _, arg := range args {
+		id, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("無效的筆記 ID: %s", arg)
+		}
+		noteIDs = append(noteIDs, id)
+	}
+
+	// 單字檔案
+	if filePath != "" {
+		words, err := readWordsFile(filePath, cardType)
+		if err != nil {
+			return nil, err
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("檔案 '%s' 中沒有單字", filePath)
+		}
+		if query != "" {
+			query = "(" + query + ") "
+		}
+		query += wordsQuery(words, cardType, keyFuriganaDictionary())
+	} else if query != "" && cardType != "" {
+		query = anki.NoteTypeQuery(cardModelName(cardType)) + " " + query
+	}
+
+	// 搜尋語法
+	if query != "" {
+		foundIDs, err := client.FindNotesContext(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("搜尋筆記失敗: %w", err)
+		}
+		noteIDs = append(noteIDs, foundIDs...)
+	}
+
+	if len(args) == 0 && query == "" {
+		return nil, fmt.Errorf("請指定筆記 ID、--query 或 --file")
+	}
+
+	return client.NotesInfoContext(ctx, uniqueIDs(noteIDs))
+}
+
+// readWordsFile 讀取單字檔案，支援字串陣列或卡片資料陣列
+func readWordsFile(filePath, cardType string) ([]string, error) {
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("無法讀取檔案: %w", err)
+	}
+
+	var items []interface{}
+	if err := json.Unmarshal(fileContent, &items); err != nil {
+		return nil, fmt.Errorf("JSON 解析失敗: %w", err)
+	}
+
+	var words []string
+	for i, item := range items {
+		switch value := item.(type) {
+		case string:
+			words = append(words, value)
+		case map[string]interface{}:
+			word := ""
+			if cardType != "" {
+				word, _ = value[cardKeyField(cardType)].(string)
+			} else if w, ok := value["核心單字"].(string); ok {
+				word = w
+			} else {
+				word, _ = value["文法要點"].(string)
+			}
+			if word == "" {
+				return nil, fmt.Errorf("項目 #%d 缺少關鍵欄位", i+1)
+			}
+			words = append(words, word)
+		default:
+			return nil, fmt.Errorf("項目 #%d 格式不正確", i+1)
+		}
+	}
+
+	return words, nil
+}
+
+// wordsQuery 建立以關鍵欄位比對多個單字的搜尋語法；dict 用來比對以振り仮名標註的關鍵欄位
+func wordsQuery(words []string, cardType string, dict *furigana.Dictionary) string {
+	cardTypes := []string{cardType}
+	if cardType == "" {
+		cardTypes = models.DefaultRegistry().Names()
+		sort.Strings(cardTypes)
+	}
+
+	var terms []string
+	for _, word := range words {
+		for _, t := range cardTypes {
+			terms = append(terms, fmt.Sprintf("(%s %s)",
+				anki.NoteTypeQuery(cardModelName(t)),
+				keyFieldQuery(cardKeyField(t), word, dict),
+			))
+		}
+	}
+
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// uniqueIDs 移除重複的 ID 並保留原本順序
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	var unique []int64
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// noteKeyValue 取得筆記用來識別的欄位值
+func noteKeyValue(note anki.Note) string {
+	if cardType, ok := cardTypeForModel(note.ModelName); ok {
+		return note.Fields[cardKeyField(cardType)].Value
+	}
+
+	// 非本工具建立的模型使用第一個欄位
+	key := ""
+	first := -1
+	for _, field := range note.Fields {
+		if first < 0 || field.Order < first {
+			first = field.Order
+			key = field.Value
+		}
+	}
+	return key
+}
+
+// printNoteSummary 列出筆記摘要
+func printNoteSummary(w io.Writer, notes []anki.Note) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\t模型\t單字/文法\t卡片數")
+	for _, note := range notes {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%d\n", note.NoteID, note.ModelName, noteKeyValue(note), len(note.Cards))
+	}
+	writer.Flush()
+}
+
+// noteCardIDs 收集筆記的所有卡片 ID
+func noteCardIDs(notes []anki.Note) []int64 {
+	var cardIDs []int64
+	for _, note := range notes {
+		cardIDs = append(cardIDs, note.Cards...)
+	}
+	return cardIDs
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"anki-japanese-cli/internal/anki"
+)
+
+// TestReadWordsFile tests reading words from string arrays and card arrays
+func TestReadWordsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		content  string
+		cardType string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:    "String array",
+			content: `["飲む", "食べる"]`,
+			want:    []string{"飲む", "食べる"},
+		},
+		{
+			name:     "Card array",
+			content:  `[{"核心單字": "飲む", "核心意義": "喝"}, {"核心單字": "猫"}]`,
+			cardType: "verb",
+			want:     []string{"飲む", "猫"},
+		},
+		{
+			name:     "Grammar card array",
+			content:  `[{"文法要點": "〜ても"}]`,
+			cardType: "grammar",
+			want:     []string{"〜ても"},
+		},
+		{
+			name:    "Grammar card array without type",
+			content: `[{"文法要點": "〜ても"}]`,
+			want:    []string{"〜ても"},
+		},
+		{
+			name:     "Missing key field",
+			content:  `[{"核心意義": "喝"}]`,
+			cardType: "verb",
+			wantErr:  true,
+		},
+		{
+			name:    "Not an array",
+			content: `{"核心單字": "飲む"}`,
+			wantErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.Repeat("w", i+1)+".json")
+			if err := os.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			words, err := readWordsFile(path, tc.cardType)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("readWordsFile() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if strings.Join(words, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("readWordsFile() = %v, want %v", words, tc.want)
+			}
+		})
+	}
+}
+
+// TestWordsQuery tests building a search query for a list of words
+func TestWordsQuery(t *testing.T) {
+	query := wordsQuery([]string{"飲む", "食べる"}, "verb", nil)
+	expected := `(("note:Japanese Verb" "核心單字:飲む") OR ("note:Japanese Verb" "核心單字:食べる"))`
+	if query != expected {
+		t.Errorf("wordsQuery() = %s\nexpected %s", query, expected)
+	}
+
+	// Without a card type every note type is searched by its own key field
+	query = wordsQuery([]string{"〜ても"}, "", nil)
+	if !strings.Contains(query, `("note:Japanese Grammar" "文法要點:〜ても")`) ||
+		!strings.Contains(query, `("note:Japanese Verb" "核心單字:〜ても")`) {
+		t.Errorf("wordsQuery() without type = %s", query)
+	}
+}
+
+// TestNoteSelectionHelpers tests ID de-duplication and note summaries
+func TestNoteSelectionHelpers(t *testing.T) {
+	ids := uniqueIDs([]int64{3, 1, 3, 2, 1})
+	if len(ids) != 3 || ids[0] != 3 || ids[1] != 1 || ids[2] != 2 {
+		t.Errorf("uniqueIDs() = %v", ids)
+	}
+
+	notes := []anki.Note{
+		{
+			NoteID:    1,
+			ModelName: "Japanese Grammar",
+			Fields:    map[string]anki.NoteField{"文法要點": {Value: "〜ても"}},
+			Cards:     []int64{10, 11},
+		},
+		{
+			NoteID:    2,
+			ModelName: "Basic",
+			Fields: map[string]anki.NoteField{
+				"Back":  {Value: "back", Order: 1},
+				"Front": {Value: "front", Order: 0},
+			},
+			Cards: []int64{20},
+		},
+	}
+
+	if key := noteKeyValue(notes[0]); key != "〜ても" {
+		t.Errorf("noteKeyValue(grammar) = %s", key)
+	}
+	if key := noteKeyValue(notes[1]); key != "front" {
+		t.Errorf("noteKeyValue(Basic) = %s", key)
+	}
+	if cardIDs := noteCardIDs(notes); len(cardIDs) != 3 {
+		t.Errorf("noteCardIDs() = %v", cardIDs)
+	}
+
+	var sb strings.Builder
+	printNoteSummary(&sb, notes)
+	if !strings.Contains(sb.String(), "〜ても") || !strings.Contains(sb.String(), "Japanese Grammar") {
+		t.Errorf("printNoteSummary() = %s", sb.String())
+	}
+}
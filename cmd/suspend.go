@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// suspendCmd represents the suspend command
+var suspendCmd = &cobra.Command{
+	Use:   "suspend [note-id...]",
+	Short: "暫停 Anki 中的日文卡片",
+	Long: `暫停筆記的所有卡片，使其不再出現在複習中。
+
+筆記的選取方式與 delete 指令相同: 筆記 ID、--query 或 --file。
+
+範例:
+  anki-japanese-cli suspend 1502298033753
+  anki-japanese-cli suspend --query='deck:日文動詞 tag:bad-batch'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetSuspended(cmd, args, true)
+	},
+}
+
+// unsuspendCmd represents the unsuspend command
+var unsuspendCmd = &cobra.Command{
+	Use:   "unsuspend [note-id...]",
+	Short: "恢復已暫停的日文卡片",
+	Long: `恢復筆記中已暫停的卡片。
+
+筆記的選取方式與 delete 指令相同: 筆記 ID、--query 或 --file。
+
+範例:
+  anki-japanese-cli unsuspend --type=verb --file=verb_cards.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSetSuspended(cmd, args, false)
+	},
+}
+
+// runSetSuspended 暫停或恢復選取筆記的所有卡片
+func runSetSuspended(cmd *cobra.Command, args []string, suspend bool) error {
+	ctx := cmd.Context()
+	action := "恢復"
+	if suspend {
+		action = "暫停"
+	}
+
+	// 載入設定
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cmd.PrintErrf("錯誤: 無法載入設定: %v\n", err)
+		return fmt.Errorf("無法載入設定: %w", err)
+	}
+
+	// 建立 Anki 客戶端
+	client := anki.NewClient(&cfg.Anki)
+
+	// 選取筆記
+	notes, err := selectNotes(ctx, client, cmd, args)
+	if err != nil {
+		cmd.PrintErrf("錯誤: %s\n", anki.FormatError(err))
+		return err
+	}
+	if len(notes) == 0 {
+		cmd.Println("找不到符合條件的筆記")
+		return nil
+	}
+
+	out := cmd.OutOrStdout()
+	cardIDs := noteCardIDs(notes)
+	fmt.Fprintf(out, "%s以下 %d 筆筆記 (共 %d 張卡片):\n", action, len(notes), len(cardIDs))
+	printNoteSummary(out, notes)
+
+	var changed bool
+	if suspend {
+		changed, err = client.SuspendCardsContext(ctx, cardIDs)
+	} else {
+		changed, err = client.UnsuspendCardsContext(ctx, cardIDs)
+	}
+	if err != nil {
+		cmd.PrintErrf("錯誤: 無法%s卡片: %s\n", action, anki.FormatError(err))
+		return fmt.Errorf("無法%s卡片: %w", action, err)
+	}
+
+	if !changed {
+		fmt.Fprintf(out, "卡片狀態未變更 (已全部%s)\n", action)
+		return nil
+	}
+
+	fmt.Fprintf(out, "✓ 成功%s %d 張卡片\n", action, len(cardIDs))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(suspendCmd)
+	rootCmd.AddCommand(unsuspendCmd)
+
+	// 定義 flags
+	addNoteSelectionFlags(suspendCmd)
+	addNoteSelectionFlags(unsuspendCmd)
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/config"
+	"anki-japanese-cli/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update [card-type]",
+	Short: "更新 Anki 中既有的日文卡片",
+	Long: `就地更新 Anki 中既有筆記的欄位，保留複習紀錄。
+
+筆記可以用筆記 ID (--id) 或關鍵欄位 (--word) 定位:
+- verb / adjective / normal: 核心單字
+- grammar: 文法要點
+
+更新內容為 JSON 格式的部分欄位 (--json 或 --file)，會與既有欄位合併後
+再以與 add 指令相同的驗證流程檢查。
+
+範例:
+  anki-japanese-cli update verb --word=飲む --json='{"例句翻譯":"喝水。"}'
+  anki-japanese-cli update normal --id=1502298033753 --json='{"重音":"1"}'
+  anki-japanese-cli update grammar --word=〜ても --file=patch.json --tags=N3,grammar`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		cardType := strings.ToLower(args[0])
+
+		// 驗證卡片類型
+		factory := models.NewCardFactory()
+		if err := factory.ValidateCardType(cardType); err != nil {
+			cmd.PrintErrf("錯誤: %v\n", err)
+			return err
+		}
+
+		// 取得選項
+		noteID, _ := cmd.Flags().GetInt64("id")
+		word, _ := cmd.Flags().GetString("word")
+		jsonStr, _ := cmd.Flags().GetString("json")
+		filePath, _ := cmd.Flags().GetString("file")
+		tags, _ := cmd.Flags().GetStringSlice("tags")
+		tagsChanged := cmd.Flags().Changed("tags")
+
+		// 檢查必要參數
+		if noteID == 0 && word == "" {
+			cmd.PrintErrln("錯誤: 請指定要更新的筆記 (--id 或 --word)")
+			return fmt.Errorf("請指定要更新的筆記")
+		}
+
+		// 讀取更新內容
+		var patch map[string]interface{}
+		if filePath != "" {
+			fileContent, err := os.ReadFile(filePath)
+			if err != nil {
+				cmd.PrintErrf("錯誤: 無法讀取檔案: %v\n", err)
+				return fmt.Errorf("無法讀取檔案: %w", err)
+			}
+			jsonStr = string(fileContent)
+		}
+		if jsonStr != "" {
+			if err := json.Unmarshal([]byte(jsonStr), &patch); err != nil {
+				cmd.PrintErrf("錯誤: JSON 解析失敗: %v\n", err)
+				return fmt.Errorf("JSON 解析失敗: %w", err)
+			}
+		}
+		if len(patch) == 0 && !tagsChanged {
+			cmd.PrintErrln("錯誤: 請提供要更新的內容 (--json、--file 或 --tags)")
+			return fmt.Errorf("請提供要更新的內容")
+		}
+
+		// 載入設定
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			cmd.PrintErrf("錯誤: 無法載入設定: %v\n", err)
+			return fmt.Errorf("無法載入設定: %w", err)
+		}
+
+		// 建立 Anki 客戶端
+		client := anki.NewClient(&cfg.Anki)
+		modelName := cardModelName(cardType)
+
+		// 定位筆記
+		if noteID == 0 {
+			// 舊版 init 建立的模型以舊版欄位名稱比對關鍵欄位，並比對以振り仮名標註的寫法
+			dict := keyFuriganaDictionary()
+			var keyQueries []string
+			for _, field := range keyFieldNames(cardType) {
+				keyQueries = append(keyQueries, keyFieldQuery(field, word, dict))
+			}
+			query := anki.NoteTypeQuery(modelName) + " (" + strings.Join(keyQueries, " OR ") + ")"
+			noteIDs, err := client.FindNotesContext(ctx, query)
+			if err != nil {
+				cmd.PrintErrf("錯誤: 搜尋筆記失敗: %s\n", anki.FormatError(err))
+				return fmt.Errorf("搜尋筆記失敗: %w", err)
+			}
+			switch len(noteIDs) {
+			case 0:
+				cmd.PrintErrf("錯誤: 找不到%s為 '%s' 的筆記\n", cardKeyField(cardType), word)
+				return fmt.Errorf("找不到筆記: %s", word)
+			case 1:
+				noteID = noteIDs[0]
+			default:
+				cmd.PrintErrf("錯誤: 找到 %d 筆%s為 '%s' 的筆記，請改用 --id 指定:\n", len(noteIDs), cardKeyField(cardType), word)
+				for _, id := range noteIDs {
+					cmd.PrintErrf("  %d\n", id)
+				}
+				return fmt.Errorf("找到多筆符合的筆記")
+			}
+		}
+
+		notes, err := client.NotesInfoContext(ctx, []int64{noteID})
+		if err != nil {
+			cmd.PrintErrf("錯誤: 無法取得筆記內容: %s\n", anki.FormatError(err))
+			return fmt.Errorf("無法取得筆記內容: %w", err)
+		}
+		if len(notes) == 0 {
+			cmd.PrintErrf("錯誤: 找不到 ID 為 %d 的筆記\n", noteID)
+			return fmt.Errorf("找不到筆記: %d", noteID)
+		}
+		note := notes[0]
+		if note.ModelName != modelName {
+			cmd.PrintErrf("錯誤: 筆記 %d 的模型為 '%s'，不是 '%s'\n", noteID, note.ModelName, modelName)
+			return fmt.Errorf("筆記模型不符: %s", note.ModelName)
+		}
+
+		// 合併並驗證 (發音的羅馬拼音轉換為平假名)
+		patch, _ = normalizeReading(patch)
+		merged := mergeNoteFields(cardType, note, patch)
+		if _, err := factory.CreateCard(cardType, merged); err != nil {
+			cmd.PrintErrf("錯誤: 更新後的卡片驗證失敗: %v\n", err)
+			return fmt.Errorf("更新後的卡片驗證失敗: %w", err)
+		}
+
+		// 檢查欄位是否存在於筆記的模型
+		noteFields := make([]string, 0, len(note.Fields))
+		for name := range note.Fields {
+			noteFields = append(noteFields, name)
+		}
+		fields, unknown := mapNoteFields(cardType, noteFieldsFromData(patch), noteFields)
+		if len(unknown) > 0 {
+			cmd.PrintErrf("錯誤: 欄位 %s 不是模型 '%s' 的欄位\n", strings.Join(unknown, ", "), modelName)
+			return fmt.Errorf("未知的欄位: %s", strings.Join(unknown, ", "))
+		}
+
+		// 更新欄位
+		if len(fields) > 0 {
+			if err := client.UpdateNoteFieldsContext(ctx, noteID, fields); err != nil {
+				cmd.PrintErrf("錯誤: 無法更新筆記: %s\n", anki.FormatError(err))
+				return fmt.Errorf("無法更新筆記: %w", err)
+			}
+		}
+
+		// 更新標籤
+		if tagsChanged {
+			if err := client.UpdateNoteTagsContext(ctx, noteID, tags); err != nil {
+				cmd.PrintErrf("錯誤: 無法更新標籤: %s\n", anki.FormatError(err))
+				return fmt.Errorf("無法更新標籤: %w", err)
+			}
+		}
+
+		cmd.Printf("✓ 成功更新筆記 (ID: %d)\n", noteID)
+		return nil
+	},
+}
+
+// cardKeyField 回傳用來識別卡片的關鍵欄位
+func cardKeyField(cardType string) string {
+	if def, ok := cardModel(cardType); ok {
+		return def.Key()
+	}
+	return "核心單字"
+}
+
+// mergeNoteFields 將更新內容合併到筆記的既有欄位；
+// 舊版欄位名稱會先對應回卡片欄位名稱，讓合併後的資料可以用目前的卡片類型驗證
+func mergeNoteFields(cardType string, note anki.Note, patch map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(note.Fields)+len(patch))
+	for name, value := range note.FieldValues() {
+		merged[cardFieldName(cardType, name)] = value
+	}
+	for name, value := range patch {
+		merged[name] = value
+	}
+	return merged
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd)
+
+	// 定義 flags
+	updateCmd.Flags().Int64("id", 0, "要更新的筆記 ID")
+	updateCmd.Flags().String("word", "", "以關鍵欄位 (核心單字/文法要點) 定位筆記")
+	updateCmd.Flags().String("json", "", "JSON 格式的更新內容")
+	updateCmd.Flags().StringP("file", "f", "", "包含更新內容的 JSON 檔案路徑")
+	updateCmd.Flags().StringSlice("tags", nil, "以逗號分隔的新標籤 (取代原有標籤)")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"testing"
+
+	"anki-japanese-cli/internal/anki"
+	"anki-japanese-cli/internal/models"
+)
+
+// TestMergeNoteFields tests merging a JSON patch into existing note fields
+func TestMergeNoteFields(t *testing.T) {
+	note := anki.Note{
+		NoteID:    1502298033753,
+		ModelName: "Japanese Verb",
+		Fields: map[string]anki.NoteField{
+			"核心單字": {Value: "飲む"},
+			"核心意義": {Value: "喝"},
+			"發音":   {Value: "のむ"},
+			"情境例句": {Value: "水を飲む"},
+			"例句翻譯": {Value: "喝永"},
+		},
+	}
+
+	merged := mergeNoteFields("verb", note, map[string]interface{}{
+		"例句翻譯": "喝水",
+		"重音":   "1",
+	})
+
+	if merged["例句翻譯"] != "喝水" {
+		t.Errorf("mergeNoteFields() did not apply patch, got %v", merged["例句翻譯"])
+	}
+	if merged["重音"] != "1" {
+		t.Errorf("mergeNoteFields() did not add missing field, got %v", merged["重音"])
+	}
+	if merged["核心單字"] != "飲む" {
+		t.Errorf("mergeNoteFields() lost existing field, got %v", merged["核心單字"])
+	}
+
+	// The merged card goes through the same validation as add
+	factory := models.NewCardFactory()
+	if _, err := factory.CreateCard("verb", merged); err != nil {
+		t.Errorf("CreateCard() on merged fields returned error: %v", err)
+	}
+
+	// Clearing a required field is rejected
+	merged = mergeNoteFields("verb", note, map[string]interface{}{"核心意義": ""})
+	if _, err := factory.CreateCard("verb", merged); err == nil {
+		t.Error("CreateCard() should reject a patch that clears a required field")
+	}
+}
+
+// TestMergeNoteFieldsLegacyModel tests patching a grammar note whose model still uses the legacy field names
+func TestMergeNoteFieldsLegacyModel(t *testing.T) {
+	note := anki.Note{
+		NoteID:    1502298033754,
+		ModelName: "Japanese Grammar",
+		Fields: map[string]anki.NoteField{
+			"文法點":   {Value: "〜てしまう"},
+			"接續規則":  {Value: "動詞て形 + しまう"},
+			"核心意義":  {Value: "表示完成或遺憾"},
+			"語感說明":  {Value: "用於不小心或遺憾的情況"},
+			"例句示範":  {Value: "財布を忘れてしまった。"},
+			"例句翻譯":  {Value: "不小心忘了錢包。"},
+			"情境課題":  {Value: "用〜てしまう描述遺憾的事"},
+			"解答範例":  {Value: "電車に乗り遅れてしまった。"},
+			"難度等級":  {Value: "N4"},
+			"易混淆文法": {Value: ""},
+		},
+	}
+
+	// A one-field typo fix passes the same validation as add
+	patch := map[string]interface{}{"例句翻譯": "不小心把錢包忘了。"}
+	merged := mergeNoteFields("grammar", note, patch)
+	if merged["文法要點"] != "〜てしまう" || merged["結構形式"] != "動詞て形 + しまう" {
+		t.Errorf("mergeNoteFields() did not map legacy fields, got %v", merged)
+	}
+	if _, ok := merged["文法點"]; ok {
+		t.Errorf("mergeNoteFields() kept legacy field name 文法點: %v", merged)
+	}
+	if _, err := models.NewCardFactory().CreateCard("grammar", merged); err != nil {
+		t.Errorf("CreateCard() on merged legacy fields returned error: %v", err)
+	}
+
+	// A patch using current field names is written back to the legacy model fields
+	patch = map[string]interface{}{"結構形式": "動詞て形 + しまう/ちゃう"}
+	fields, unknown := mapNoteFields("grammar", noteFieldsFromData(patch), []string{"文法點", "接續規則", "核心意義"})
+	if len(unknown) > 0 || fields["接續規則"] != "動詞て形 + しまう/ちゃう" {
+		t.Errorf("mapNoteFields() = %v, unknown %v", fields, unknown)
+	}
+}
+
+// TestKeyFieldNames tests the model field names searched by update --word
+func TestKeyFieldNames(t *testing.T) {
+	if got := keyFieldNames("grammar"); len(got) != 2 || got[0] != "文法要點" || got[1] != "文法點" {
+		t.Errorf("keyFieldNames(grammar) = %v", got)
+	}
+	if got := keyFieldNames("verb"); len(got) != 1 || got[0] != "核心單字" {
+		t.Errorf("keyFieldNames(verb) = %v", got)
+	}
+}
+
+// TestCardKeyField tests the identifying field of each card type
+func TestCardKeyField(t *testing.T) {
+	expected := map[string]string{
+		"verb":      "核心單字",
+		"adjective": "核心單字",
+		"normal":    "核心單字",
+		"grammar":   "文法要點",
+	}
+	for cardType, field := range expected {
+		if got := cardKeyField(cardType); got != field {
+			t.Errorf("cardKeyField(%s) = %s, expected %s", cardType, got, field)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"anki-japanese-cli/internal/anki"
+)
+
+// fieldRename 需要重新命名的模型欄位 (保留欄位內容)
+type fieldRename struct {
+	Old string
+	New string
+}
+
+// fieldAdd 需要新增的模型欄位
+type fieldAdd struct {
+	Name  string
+	Index int // 新增的位置 (從 0 開始)
+}
+
+// templateChange 需要更新的卡片模板
+type templateChange struct {
+	Name     string
+	OldFront string
+	NewFront string
+	OldBack  string
+	NewBack  string
+}
+
+// modelUpgrade 既有模型與目前定義之間的差異
+type modelUpgrade struct {
+	ModelName string
+	Renames   []fieldRename
+	Adds      []fieldAdd
+	Extra     []string         // 模型中有但定義中沒有的欄位 (保留不刪除)
+	Templates []templateChange // 內容不同的卡片模板
+	OldCSS    string
+	NewCSS    string
+
+	NewTemplates   []anki.CardTemplateConfig // 模型中還沒有的卡片模板 (例如新啟用的卡片方向)
+	ExtraTemplates []string                  // 模型中有但設定中沒有的卡片模板 (保留不刪除)
+}
+
+// cssChanged 回傳 CSS 是否需要更新
+func (u *modelUpgrade) cssChanged() bool {
+	return strings.TrimSpace(u.OldCSS) != strings.TrimSpace(u.NewCSS)
+}
+
+// upToDate 回傳模型是否已與目前定義一致
+func (u *modelUpgrade) upToDate() bool {
+	return len(u.Renames) == 0 && len(u.Adds) == 0 && len(u.Templates) == 0 && len(u.NewTemplates) == 0 && !u.cssChanged()
+}
+
+// planModelUpgrade 比較既有模型的欄位、模板與 CSS 和目前的模型設定，整理出需要的更新
+func planModelUpgrade(cardType string, desired anki.ModelConfig, fields []string, templates map[string]map[string]string, css string) *modelUpgrade {
+	upgrade := &modelUpgrade{
+		ModelName: desired.ModelName,
+		OldCSS:    css,
+		NewCSS:    desired.CSS,
+	}
+
+	// 欄位: 舊版名稱改為重新命名，其餘缺少的欄位依定義順序新增
+	current := append([]string(nil), fields...)
+	desiredSet := make(map[string]bool, len(desired.InOrderFields))
+	for _, field := range desired.InOrderFields {
+		desiredSet[field] = true
+	}
+	for _, field := range desired.InOrderFields {
+		if indexOf(current, field) >= 0 {
+			continue
+		}
+		legacy, ok := legacyFieldNames[cardType][field]
+		if !ok || desiredSet[legacy] {
+			continue
+		}
+		if i := indexOf(current, legacy); i >= 0 {
+			upgrade.Renames = append(upgrade.Renames, fieldRename{Old: legacy, New: field})
+			current[i] = field
+		}
+	}
+	for i, field := range desired.InOrderFields {
+		if indexOf(current, field) >= 0 {
+			continue
+		}
+		index := i
+		if index > len(current) {
+			index = len(current)
+		}
+		upgrade.Adds = append(upgrade.Adds, fieldAdd{Name: field, Index: index})
+		current = append(current[:index], append([]string{field}, current[index:]...)...)
+	}
+	for _, field := range current {
+		if !desiredSet[field] {
+			upgrade.Extra = append(upgrade.Extra, field)
+		}
+	}
+
+	// 模板: 依名稱比對；模型只有單一且名稱不在設定中的模板時，
+	// 對應到第一個 (主要) 模板 (例如 Anki 預設的 "Card 1")
+	var existingNames []string
+	for name := range templates {
+		existingNames = append(existingNames, name)
+	}
+	sort.Strings(existingNames)
+
+	desiredNames := make(map[string]bool, len(desired.CardTemplates))
+	for _, tmpl := range desired.CardTemplates {
+		desiredNames[tmpl.Name] = true
+	}
+	matched := make(map[string]bool, len(existingNames))
+
+	for i, tmpl := range desired.CardTemplates {
+		name := tmpl.Name
+		existing, ok := templates[name]
+		if !ok && i == 0 && len(existingNames) == 1 && !desiredNames[existingNames[0]] {
+			name = existingNames[0]
+			existing, ok = templates[name]
+		}
+		if !ok {
+			upgrade.NewTemplates = append(upgrade.NewTemplates, tmpl)
+			continue
+		}
+		matched[name] = true
+		if existing["Front"] == tmpl.Front && existing["Back"] == tmpl.Back {
+			continue
+		}
+		upgrade.Templates = append(upgrade.Templates, templateChange{
+			Name:     name,
+			OldFront: existing["Front"],
+			NewFront: tmpl.Front,
+			OldBack:  existing["Back"],
+			NewBack:  tmpl.Back,
+		})
+	}
+	for _, name := range existingNames {
+		if !matched[name] {
+			upgrade.ExtraTemplates = append(upgrade.ExtraTemplates, name)
+		}
+	}
+
+	return upgrade
+}
+
+// indexOf 回傳字串在切片中的位置，找不到時為 -1
+func indexOf(values []string, target string) int {
+	for i, value := range values {
+		if value == target {
+			return i
+		}
+	}
+	return -1
+}
+
+// printModelUpgrade 列出模型需要的更新；showDiff 為 true 時逐行列出模板與 CSS 的差異
+func printModelUpgrade(w io.Writer, upgrade *modelUpgrade, showDiff bool) {
+	if upgrade.upToDate() {
+		fmt.Fprintf(w, "模型 '%s' 已是最新版本\n", upgrade.ModelName)
+	} else {
+		fmt.Fprintf(w, "模型 '%s' 需要以下更新:\n", upgrade.ModelName)
+	}
+
+	for _, rename := range upgrade.Renames {
+		fmt.Fprintf(w, "  欄位: 重新命名 '%s' → '%s'\n", rename.Old, rename.New)
+	}
+	for _, add := range upgrade.Adds {
+		fmt.Fprintf(w, "  欄位: 新增 '%s' (位置 %d)\n", add.Name, add.Index+1)
+	}
+	for _, tmpl := range upgrade.NewTemplates {
+		fmt.Fprintf(w, "  模板: 新增 '%s'\n", tmpl.Name)
+	}
+	for _, tmpl := range upgrade.Templates {
+		fmt.Fprintf(w, "  模板: 更新 '%s'\n", tmpl.Name)
+		if showDiff {
+			printLineDiff(w, "正面", tmpl.OldFront, tmpl.NewFront)
+			printLineDiff(w, "背面", tmpl.OldBack, tmpl.NewBack)
+		}
+	}
+	if upgrade.cssChanged() {
+		fmt.Fprintln(w, "  CSS: 更新樣式")
+		if showDiff {
+			printLineDiff(w, "CSS", upgrade.OldCSS, upgrade.NewCSS)
+		}
+	}
+
+	for _, field := range upgrade.Extra {
+		fmt.Fprintf(w, "  注意: 欄位 '%s' 不在卡片定義中，將保留不變\n", field)
+	}
+	for _, name := range upgrade.ExtraTemplates {
+		fmt.Fprintf(w, "  注意: 卡片模板 '%s' 不在目前的設定中，將保留不變\n", name)
+	}
+}
+
+// printLineDiff 逐行列出兩段文字的差異 (- 為目前內容，+ 為更新後內容)
+func printLineDiff(w io.Writer, label, oldText, newText string) {
+	lines := lineDiff(oldText, newText)
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "    --- %s\n", label)
+	for _, line := range lines {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
+
+// lineDiff 以最長共同子序列比較兩段文字，回傳刪除 ("- ") 與新增 ("+ ") 的行
+func lineDiff(oldText, newText string) []string {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// lcs[i][j] 為 a[i:] 與 b[j:] 的最長共同子序列長度
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, "- "+a[i])
+			i++
+		default:
+			diff = append(diff, "+ "+b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, "- "+a[i])
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, "+ "+b[j])
+	}
+	return diff
+}
+
+// splitLines 將文字分行並去除每行前後的空白，忽略空行
+func splitLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// fetchModelState 以單一 multi 請求取得既有模型的欄位、模板與 CSS
+func fetchModelState(ctx context.Context, client *anki.Client, modelName string) ([]string, map[string]map[string]string, string, error) {
+	batch := client.NewBatch()
+	fieldsIdx := batch.QueueModelFieldNames(modelName)
+	templatesIdx := batch.QueueModelTemplates(modelName)
+	stylingIdx := batch.QueueModelStyling(modelName)
+
+	results, err := batch.ExecuteContext(ctx)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	fields, err := results[fieldsIdx].Strings()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("無法取得模型欄位: %w", err)
+	}
+	var templates map[string]map[string]string
+	if err := results[templatesIdx].Decode(&templates); err != nil {
+		return nil, nil, "", fmt.Errorf("無法取得模型模板: %w", err)
+	}
+	css, err := results[stylingIdx].Styling()
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("無法取得模型樣式: %w", err)
+	}
+
+	return fields, templates, css, nil
+}
+
+// queueModelUpgrade 將模型更新的動作加入批次 (先處理欄位，再更新模板與 CSS)，
+// 回傳每個動作的說明，依加入批次的順序排列
+func queueModelUpgrade(batch *anki.Batch, upgrade *modelUpgrade) []string {
+	var steps []string
+
+	for _, rename := range upgrade.Renames {
+		batch.QueueModelFieldRename(upgrade.ModelName, rename.Old, rename.New)
+		steps = append(steps, fmt.Sprintf("重新命名欄位 '%s'", rename.Old))
+	}
+	for _, add := range upgrade.Adds {
+		batch.QueueModelFieldAdd(upgrade.ModelName, add.Name, add.Index)
+		steps = append(steps, fmt.Sprintf("新增欄位 '%s'", add.Name))
+	}
+	if len(upgrade.Templates) > 0 {
+		templates := make(map[string]map[string]string, len(upgrade.Templates))
+		for _, tmpl := range upgrade.Templates {
+			templates[tmpl.Name] = map[string]string{"Front": tmpl.NewFront, "Back": tmpl.NewBack}
+		}
+		batch.QueueUpdateModelTemplates(upgrade.ModelName, templates)
+		steps = append(steps, "更新模板")
+	}
+	for _, tmpl := range upgrade.NewTemplates {
+		batch.QueueModelTemplateAdd(upgrade.ModelName, tmpl)
+		steps = append(steps, fmt.Sprintf("新增模板 '%s'", tmpl.Name))
+	}
+	if upgrade.cssChanged() {
+		batch.QueueUpdateModelStyling(upgrade.ModelName, upgrade.NewCSS)
+		steps = append(steps, "更新樣式")
+	}
+
+	return steps
+}
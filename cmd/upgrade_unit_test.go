@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"anki-japanese-cli/internal/anki"
+)
+
+// TestPlanModelUpgrade tests diffing an existing legacy model against the current definition
+func TestPlanModelUpgrade(t *testing.T) {
+	desired := anki.ModelConfig{
+		ModelName:     "Japanese Grammar",
+		InOrderFields: []string{"文法要點", "意義說明", "例句示範", "記憶技巧"},
+		CSS:           ".card { color: white; }",
+		CardTemplates: []anki.CardTemplateConfig{
+			{Name: "Japanese Grammar", Front: "{{文法要點}}", Back: "{{意義說明}}\n{{例句示範}}"},
+		},
+	}
+
+	fields := []string{"文法點", "核心意義", "記憶技巧", "備註"}
+	templates := map[string]map[string]string{
+		"Card 1": {"Front": "{{文法點}}", "Back": "{{核心意義}}"},
+	}
+
+	upgrade := planModelUpgrade("grammar", desired, fields, templates, ".card { color: black; }")
+
+	expectedRenames := []fieldRename{{Old: "文法點", New: "文法要點"}, {Old: "核心意義", New: "意義說明"}}
+	if len(upgrade.Renames) != len(expectedRenames) {
+		t.Fatalf("Renames = %v, expected %v", upgrade.Renames, expectedRenames)
+	}
+	for i, rename := range expectedRenames {
+		if upgrade.Renames[i] != rename {
+			t.Errorf("Renames[%d] = %v, expected %v", i, upgrade.Renames[i], rename)
+		}
+	}
+
+	if len(upgrade.Adds) != 1 || upgrade.Adds[0] != (fieldAdd{Name: "例句示範", Index: 2}) {
+		t.Errorf("Adds = %v, expected [{例句示範 2}]", upgrade.Adds)
+	}
+	if len(upgrade.Extra) != 1 || upgrade.Extra[0] != "備註" {
+		t.Errorf("Extra = %v, expected [備註]", upgrade.Extra)
+	}
+
+	// The single existing template is matched even though its name differs
+	if len(upgrade.Templates) != 1 || upgrade.Templates[0].Name != "Card 1" {
+		t.Fatalf("Templates = %v, expected an update of 'Card 1'", upgrade.Templates)
+	}
+	if !upgrade.cssChanged() || upgrade.upToDate() {
+		t.Error("expected the CSS change to be detected")
+	}
+
+	var buf bytes.Buffer
+	printModelUpgrade(&buf, upgrade, true)
+	output := buf.String()
+	for _, expected := range []string{"重新命名 '文法點' → '文法要點'", "新增 '例句示範' (位置 3)", "- {{核心意義}}", "+ {{例句示範}}", "'備註'"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("printModelUpgrade() output missing %q:\n%s", expected, output)
+		}
+	}
+}
+
+// TestPlanModelUpgradeUpToDate tests that a current model needs no changes
+func TestPlanModelUpgradeUpToDate(t *testing.T) {
+	desired := anki.ModelConfig{
+		ModelName:     "Japanese Verb",
+		InOrderFields: []string{"核心單字", "發音"},
+		CSS:           ".card {}",
+		CardTemplates: []anki.CardTemplateConfig{
+			{Name: "Japanese Verb", Front: "{{核心單字}}", Back: "{{發音}}"},
+		},
+	}
+	templates := map[string]map[string]string{
+		"Japanese Verb": {"Front": "{{核心單字}}", "Back": "{{發音}}"},
+	}
+
+	upgrade := planModelUpgrade("verb", desired, []string{"核心單字", "發音"}, templates, ".card {}\n")
+	if !upgrade.upToDate() {
+		t.Errorf("planModelUpgrade() = %+v, expected no changes", upgrade)
+	}
+}
+
+// TestPlanModelUpgradeNewTemplates tests that newly enabled card directions are added as templates
+func TestPlanModelUpgradeNewTemplates(t *testing.T) {
+	desired := anki.ModelConfig{
+		ModelName:     "Japanese Verb",
+		InOrderFields: []string{"核心單字", "發音"},
+		CSS:           ".card {}",
+		CardTemplates: []anki.CardTemplateConfig{
+			{Name: "Japanese Verb", Front: "{{核心單字}}", Back: "{{發音}}"},
+			{Name: "讀音", Front: "{{核心單字}}", Back: "{{FrontSide}}{{發音}}"},
+		},
+	}
+	templates := map[string]map[string]string{
+		"Japanese Verb": {"Front": "{{核心單字}}", "Back": "{{發音}}"},
+		"聽力":            {"Front": "{{音檔}}", "Back": "{{核心單字}}"},
+	}
+
+	upgrade := planModelUpgrade("verb", desired, []string{"核心單字", "發音"}, templates, ".card {}")
+	if upgrade.upToDate() {
+		t.Fatal("expected the new template to be detected")
+	}
+	if len(upgrade.NewTemplates) != 1 || upgrade.NewTemplates[0].Name != "讀音" {
+		t.Errorf("NewTemplates = %v, expected [讀音]", upgrade.NewTemplates)
+	}
+	if len(upgrade.Templates) != 0 {
+		t.Errorf("Templates = %v, expected no updates", upgrade.Templates)
+	}
+	if len(upgrade.ExtraTemplates) != 1 || upgrade.ExtraTemplates[0] != "聽力" {
+		t.Errorf("ExtraTemplates = %v, expected [聽力]", upgrade.ExtraTemplates)
+	}
+
+	var buf bytes.Buffer
+	printModelUpgrade(&buf, upgrade, false)
+	for _, expected := range []string{"模板: 新增 '讀音'", "'聽力' 不在目前的設定中"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("printModelUpgrade() output missing %q:\n%s", expected, buf.String())
+		}
+	}
+
+	client := anki.NewClient(nil)
+	batch := client.NewBatch()
+	steps := queueModelUpgrade(batch, upgrade)
+	if len(steps) != 1 || batch.Len() != 1 {
+		t.Errorf("queueModelUpgrade() = %v (%d actions), expected a single modelTemplateAdd", steps, batch.Len())
+	}
+}
+
+// TestLineDiff tests the line based diff used by --dry-run
+func TestLineDiff(t *testing.T) {
+	diff := lineDiff("a\nb\nc", "a\nc\nd")
+	expected := []string{"- b", "+ d"}
+	if len(diff) != len(expected) {
+		t.Fatalf("lineDiff() = %v, expected %v", diff, expected)
+	}
+	for i := range expected {
+		if diff[i] != expected[i] {
+			t.Errorf("lineDiff()[%d] = %q, expected %q", i, diff[i], expected[i])
+		}
+	}
+
+	if diff := lineDiff("same", "  same  "); len(diff) != 0 {
+		t.Errorf("lineDiff() = %v, expected no differences", diff)
+	}
+}
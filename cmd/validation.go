@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"anki-japanese-cli/internal/models"
+)
+
+// cardIssues 單張卡片的驗證錯誤或警告
+type cardIssues struct {
+	Position int     // 在輸入資料中的位置 (從 0 開始)
+	Key      string  // 核心單字或文法要點 (可能為空)
+	Issues   []error // 所有驗證錯誤或警告
+}
+
+// newCardIssues 整理單張卡片的驗證問題
+func newCardIssues(position int, cardType string, data map[string]interface{}, issues []error) cardIssues {
+	key, _ := data[cardKeyField(cardType)].(string)
+	return cardIssues{
+		Position: position,
+		Key:      key,
+		Issues:   issues,
+	}
+}
+
+// validationErrorList 將驗證錯誤展開為個別欄位的錯誤
+func validationErrorList(err error) []error {
+	var validationErrs models.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return []error{err}
+	}
+	return validationErrs.Unwrap()
+}
+
+// printValidationReport 列出批次中所有驗證失敗的卡片
+func printValidationReport(w io.Writer, invalid []cardIssues, total int) {
+	fmt.Fprintf(w, "發現 %d/%d 張卡片驗證失敗:\n", len(invalid), total)
+	printCardIssues(w, invalid)
+}
+
+// maxReportedWarnings 警告報告中逐一列出的卡片數量上限；
+// 幾乎每張卡片都可能有警告 (例如圖片提示)，大型檔案只保留前幾張並回報總數
+const maxReportedWarnings = 20
+
+// printWarningReport 列出批次中有警告的卡片 (不影響新增)；
+// warned 只包含前 maxReportedWarnings 張，total 為有警告的卡片總數
+func printWarningReport(w io.Writer, warned []cardIssues, total int) {
+	fmt.Fprintf(w, "%d 張卡片有警告 (不影響新增):\n", total)
+	printCardIssues(w, warned)
+	if total > len(warned) {
+		fmt.Fprintf(w, "  … 另有 %d 張卡片有警告\n", total-len(warned))
+	}
+}
+
+// printCardIssues 依卡片列出驗證問題
+func printCardIssues(w io.Writer, cards []cardIssues) {
+	for _, card := range cards {
+		if card.Key != "" {
+			fmt.Fprintf(w, "  卡片 #%d (%s):\n", card.Position+1, card.Key)
+		} else {
+			fmt.Fprintf(w, "  卡片 #%d:\n", card.Position+1)
+		}
+		for _, err := range card.Issues {
+			fmt.Fprintf(w, "    - %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"anki-japanese-cli/internal/models"
+)
+
+// TestValidationReport tests collecting every validation error of a batch
+func TestValidationReport(t *testing.T) {
+	factory := models.NewCardFactory()
+	cardData := []map[string]interface{}{
+		{"核心單字": "飲む", "核心意義": "喝"},
+		{"核心單字": "食べる", "核心意義": "吃", "發音": "たべる", "情境例句": "ご飯を食べる", "例句翻譯": "吃飯"},
+		{"核心意義": "看"},
+	}
+
+	var invalid []cardIssues
+	for i, data := range cardData {
+		if _, err := factory.CreateCard("verb", data); err != nil {
+			invalid = append(invalid, newCardIssues(i, "verb", data, validationErrorList(err)))
+		}
+	}
+
+	if len(invalid) != 2 {
+		t.Fatalf("expected 2 invalid cards, got %d", len(invalid))
+	}
+	if invalid[0].Position != 0 || invalid[0].Key != "飲む" || len(invalid[0].Issues) != 3 {
+		t.Errorf("invalid[0] = %+v, expected card #1 飲む with 3 errors", invalid[0])
+	}
+	if invalid[1].Position != 2 || len(invalid[1].Issues) != 4 {
+		t.Errorf("invalid[1] = %+v, expected card #3 with 4 errors", invalid[1])
+	}
+
+	var out bytes.Buffer
+	printValidationReport(&out, invalid, len(cardData))
+	report := out.String()
+	for _, want := range []string{"2/3", "卡片 #1 (飲む)", "卡片 #3:", "'發音'", "'核心單字'"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+// TestWarningReport tests that the warning report lists the kept cards and the total count
+func TestWarningReport(t *testing.T) {
+	warned := []cardIssues{
+		{Position: 0, Key: "飲む", Issues: []error{models.NewValidationWarning("圖片提示", "建議填寫")}},
+		{Position: 4, Key: "食べる", Issues: []error{models.NewValidationWarning("圖片提示", "建議填寫")}},
+	}
+
+	var out bytes.Buffer
+	printWarningReport(&out, warned, 250)
+	report := out.String()
+	for _, want := range []string{"250 張卡片有警告", "卡片 #1 (飲む)", "卡片 #5 (食べる)", "另有 248 張"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+
+	out.Reset()
+	printWarningReport(&out, warned, len(warned))
+	if strings.Contains(out.String(), "另有") {
+		t.Errorf("report for every warned card should not mention more cards:\n%s", out.String())
+	}
+}
@@ -1,6 +1,7 @@
 package anki
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -13,8 +14,8 @@ type NoteInfo struct {
 	Options   map[string]interface{} `json:"options,omitempty"`
 }
 
-// AddNote adds a single note to Anki
-func (c *Client) AddNote(note NoteInfo) (int64, error) {
+// noteParams converts a note to the format expected by the API
+func noteParams(note NoteInfo) map[string]interface{} {
 	// Create the note map without options first
 	noteMap := map[string]interface{}{
 		"deckName":  note.DeckName,
@@ -32,11 +33,81 @@ func (c *Client) AddNote(note NoteInfo) (int64, error) {
 		noteMap["options"] = note.Options
 	}
 
+	return noteMap
+}
+
+// addNotesParams builds the parameters of an addNotes request
+func addNotesParams(notes []NoteInfo) map[string]interface{} {
+	apiNotes := make([]map[string]interface{}, len(notes))
+	for i, note := range notes {
+		apiNotes[i] = noteParams(note)
+	}
+
+	return map[string]interface{}{
+		"notes": apiNotes,
+	}
+}
+
+// parseNoteIDs converts an addNotes result to an int64 slice (note IDs)
+func parseNoteIDs(result interface{}) ([]int64, error) {
+	noteIDs, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type: %T", result)
+	}
+
+	var ids []int64
+	for _, id := range noteIDs {
+		// Note IDs can be null if the note wasn't added
+		if id == nil {
+			ids = append(ids, 0)
+			continue
+		}
+
+		floatID, ok := id.(float64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected ID type: %T", id)
+		}
+		ids = append(ids, int64(floatID))
+	}
+
+	return ids, nil
+}
+
+// QueueAddNote queues an addNote action in the batch
+func (b *Batch) QueueAddNote(note NoteInfo) int {
+	return b.Add("addNote", map[string]interface{}{"note": noteParams(note)})
+}
+
+// QueueAddNotes queues an addNotes action in the batch.
+// Use BatchResult.NoteIDs to decode its result.
+func (b *Batch) QueueAddNotes(notes []NoteInfo) int {
+	return b.Add("addNotes", addNotesParams(notes))
+}
+
+// QueueCanAddNotes queues a canAddNotes action in the batch.
+// Use BatchResult.Bools to decode its result.
+func (b *Batch) QueueCanAddNotes(notes []NoteInfo) int {
+	return b.Add("canAddNotes", addNotesParams(notes))
+}
+
+// QueueCreateDeck queues a createDeck action in the batch.
+// createDeck leaves existing decks untouched, so it can be used to ensure a deck exists.
+func (b *Batch) QueueCreateDeck(deckName string) int {
+	return b.Add("createDeck", map[string]interface{}{"deck": deckName})
+}
+
+// AddNote adds a single note to Anki
+func (c *Client) AddNote(note NoteInfo) (int64, error) {
+	return c.AddNoteContext(context.Background(), note)
+}
+
+// AddNoteContext adds a single note to Anki using the given context
+func (c *Client) AddNoteContext(ctx context.Context, note NoteInfo) (int64, error) {
 	params := map[string]interface{}{
-		"note": noteMap,
+		"note": noteParams(note),
 	}
 
-	result, err := c.Call("addNote", params)
+	result, err := c.CallContext(ctx, "addNote", params)
 	if err != nil {
 		return 0, fmt.Errorf("failed to add note: %w", err)
 	}
@@ -52,65 +123,111 @@ func (c *Client) AddNote(note NoteInfo) (int64, error) {
 
 // AddNotes adds multiple notes to Anki
 func (c *Client) AddNotes(notes []NoteInfo) ([]int64, error) {
-	// Convert notes to the format expected by the API
-	apiNotes := make([]map[string]interface{}, len(notes))
-	for i, note := range notes {
-		// Create the note map without options first
-		noteMap := map[string]interface{}{
-			"deckName":  note.DeckName,
-			"modelName": note.ModelName,
-			"fields":    note.Fields,
-		}
+	return c.AddNotesContext(context.Background(), notes)
+}
 
-		// Add tags if present
-		if note.Tags != nil && len(note.Tags) > 0 {
-			noteMap["tags"] = note.Tags
-		}
+// AddNotesContext adds multiple notes to Anki using the given context
+func (c *Client) AddNotesContext(ctx context.Context, notes []NoteInfo) ([]int64, error) {
+	result, err := c.CallContext(ctx, "addNotes", addNotesParams(notes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add notes: %w", err)
+	}
 
-		// Add options if present
-		if note.Options != nil && len(note.Options) > 0 {
-			noteMap["options"] = note.Options
-		}
+	return parseNoteIDs(result)
+}
 
-		apiNotes[i] = noteMap
-	}
+// CanAddNotes reports for each note whether it could be added, i.e. it is not a
+// duplicate and its model and deck exist
+func (c *Client) CanAddNotes(notes []NoteInfo) ([]bool, error) {
+	return c.CanAddNotesContext(context.Background(), notes)
+}
 
-	params := map[string]interface{}{
-		"notes": apiNotes,
+// CanAddNotesContext reports for each note whether it could be added using the given context
+func (c *Client) CanAddNotesContext(ctx context.Context, notes []NoteInfo) ([]bool, error) {
+	if len(notes) == 0 {
+		return nil, nil
 	}
 
-	result, err := c.Call("addNotes", params)
+	result, err := c.CallContext(ctx, "canAddNotes", addNotesParams(notes))
 	if err != nil {
-		return nil, fmt.Errorf("failed to add notes: %w", err)
+		return nil, fmt.Errorf("failed to check notes: %w", err)
 	}
 
-	// Convert the result to an int64 slice (note IDs)
-	noteIDs, ok := result.([]interface{})
+	return parseBools(result)
+}
+
+// parseBools converts a canAddNotes result to a bool slice
+func parseBools(result interface{}) ([]bool, error) {
+	items, ok := result.([]interface{})
 	if !ok {
 		return nil, fmt.Errorf("unexpected result type: %T", result)
 	}
 
-	var ids []int64
-	for _, id := range noteIDs {
-		// Note IDs can be null if the note wasn't added
-		if id == nil {
-			ids = append(ids, 0)
-			continue
+	values := make([]bool, 0, len(items))
+	for _, item := range items {
+		value, ok := item.(bool)
+		if !ok {
+			return nil, fmt.Errorf("unexpected item type: %T", item)
 		}
+		values = append(values, value)
+	}
 
-		floatID, ok := id.(float64)
-		if !ok {
-			return nil, fmt.Errorf("unexpected ID type: %T", id)
+	return values, nil
+}
+
+// AddNoteResult is the outcome of adding one note of a batch
+type AddNoteResult struct {
+	Index  int      // position of the note in the submitted slice
+	Note   NoteInfo // the submitted note
+	NoteID int64    // ID of the new note, 0 if it was not added
+	Err    error    // reason the note was not added
+}
+
+// AddNotesWithResults adds multiple notes to Anki and reports the outcome of each one
+func (c *Client) AddNotesWithResults(notes []NoteInfo) ([]AddNoteResult, error) {
+	return c.AddNotesWithResultsContext(context.Background(), notes)
+}
+
+// AddNotesWithResultsContext adds multiple notes to Anki and reports the outcome of each one using the given context.
+// Unlike AddNotesContext, every note is sent as its own addNote action of a single multi
+// request, so the reason a note was rejected is preserved instead of being reduced to ID 0.
+func (c *Client) AddNotesWithResultsContext(ctx context.Context, notes []NoteInfo) ([]AddNoteResult, error) {
+	if len(notes) == 0 {
+		return nil, nil
+	}
+
+	batch := c.NewBatch()
+	for _, note := range notes {
+		batch.QueueAddNote(note)
+	}
+
+	results, err := batch.ExecuteContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add notes: %w", err)
+	}
+
+	addResults := make([]AddNoteResult, len(notes))
+	for i, result := range results {
+		addResults[i] = AddNoteResult{Index: i, Note: notes[i]}
+		noteID, err := result.Int64()
+		if err != nil {
+			addResults[i].Err = err
+			continue
 		}
-		ids = append(ids, int64(floatID))
+		addResults[i].NoteID = noteID
 	}
 
-	return ids, nil
+	return addResults, nil
 }
 
 // DeckNames returns a list of all deck names
 func (c *Client) DeckNames() ([]string, error) {
-	result, err := c.Call("deckNames", nil)
+	return c.DeckNamesContext(context.Background())
+}
+
+// DeckNamesContext returns a list of all deck names using the given context
+func (c *Client) DeckNamesContext(ctx context.Context) ([]string, error) {
+	result, err := c.CallContext(ctx, "deckNames", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get deck names: %w", err)
 	}
@@ -135,11 +252,16 @@ func (c *Client) DeckNames() ([]string, error) {
 
 // CreateDeck creates a new deck
 func (c *Client) CreateDeck(deckName string) (int64, error) {
+	return c.CreateDeckContext(context.Background(), deckName)
+}
+
+// CreateDeckContext creates a new deck using the given context
+func (c *Client) CreateDeckContext(ctx context.Context, deckName string) (int64, error) {
 	params := map[string]interface{}{
 		"deck": deckName,
 	}
 
-	result, err := c.Call("createDeck", params)
+	result, err := c.CallContext(ctx, "createDeck", params)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create deck: %w", err)
 	}
@@ -155,7 +277,12 @@ func (c *Client) CreateDeck(deckName string) (int64, error) {
 
 // DeckExists checks if a deck with the given name exists
 func (c *Client) DeckExists(deckName string) (bool, error) {
-	decks, err := c.DeckNames()
+	return c.DeckExistsContext(context.Background(), deckName)
+}
+
+// DeckExistsContext checks if a deck with the given name exists using the given context
+func (c *Client) DeckExistsContext(ctx context.Context, deckName string) (bool, error) {
+	decks, err := c.DeckNamesContext(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -171,13 +298,18 @@ func (c *Client) DeckExists(deckName string) (bool, error) {
 
 // EnsureDeckExists creates the deck if it doesn't exist
 func (c *Client) EnsureDeckExists(deckName string) error {
-	exists, err := c.DeckExists(deckName)
+	return c.EnsureDeckExistsContext(context.Background(), deckName)
+}
+
+// EnsureDeckExistsContext creates the deck if it doesn't exist using the given context
+func (c *Client) EnsureDeckExistsContext(ctx context.Context, deckName string) error {
+	exists, err := c.DeckExistsContext(ctx, deckName)
 	if err != nil {
 		return err
 	}
 
 	if !exists {
-		_, err = c.CreateDeck(deckName)
+		_, err = c.CreateDeckContext(ctx, deckName)
 		if err != nil {
 			return err
 		}
@@ -185,3 +317,49 @@ func (c *Client) EnsureDeckExists(deckName string) error {
 
 	return nil
 }
+
+// SuspendCards suspends the given cards.
+// It returns false if all of the cards were already suspended.
+func (c *Client) SuspendCards(cardIDs []int64) (bool, error) {
+	return c.SuspendCardsContext(context.Background(), cardIDs)
+}
+
+// SuspendCardsContext suspends the given cards using the given context
+func (c *Client) SuspendCardsContext(ctx context.Context, cardIDs []int64) (bool, error) {
+	return c.setSuspended(ctx, "suspend", cardIDs)
+}
+
+// UnsuspendCards unsuspends the given cards.
+// It returns false if none of the cards were suspended.
+func (c *Client) UnsuspendCards(cardIDs []int64) (bool, error) {
+	return c.UnsuspendCardsContext(context.Background(), cardIDs)
+}
+
+// UnsuspendCardsContext unsuspends the given cards using the given context
+func (c *Client) UnsuspendCardsContext(ctx context.Context, cardIDs []int64) (bool, error) {
+	return c.setSuspended(ctx, "unsuspend", cardIDs)
+}
+
+// setSuspended performs a suspend or unsuspend action
+func (c *Client) setSuspended(ctx context.Context, action string, cardIDs []int64) (bool, error) {
+	if len(cardIDs) == 0 {
+		return false, nil
+	}
+
+	params := map[string]interface{}{
+		"cards": cardIDs,
+	}
+
+	result, err := c.CallContext(ctx, action, params)
+	if err != nil {
+		return false, fmt.Errorf("failed to %s cards: %w", action, err)
+	}
+
+	// Convert the result to a bool
+	changed, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected result type: %T", result)
+	}
+
+	return changed, nil
+}
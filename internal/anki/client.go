@@ -2,6 +2,7 @@ package anki
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -77,6 +78,12 @@ func (c *Client) SetRetryOptions(retries int, retryDelay time.Duration) {
 
 // Call makes a request to the Anki Connect API
 func (c *Client) Call(action string, params interface{}) (interface{}, error) {
+	return c.CallContext(context.Background(), action, params)
+}
+
+// CallContext makes a request to the Anki Connect API using the given context.
+// Retries are abandoned as soon as the context is cancelled or its deadline expires.
+func (c *Client) CallContext(ctx context.Context, action string, params interface{}) (interface{}, error) {
 	req := Request{
 		Action:  action,
 		Version: APIVersion,
@@ -89,27 +96,42 @@ func (c *Client) Call(action string, params interface{}) (interface{}, error) {
 	// Retry logic
 	for attempt := 0; attempt <= c.retries; attempt++ {
 		if attempt > 0 {
-			// Wait before retrying
-			time.Sleep(c.retryDelay)
+			// Wait before retrying, unless the context is done first
+			timer := time.NewTimer(c.retryDelay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, fmt.Errorf("request cancelled after %d attempts: %w", attempt, ctx.Err())
+			case <-timer.C:
+			}
 		}
 
-		result, lastErr = c.doRequest(req)
+		result, lastErr = c.doRequest(ctx, req)
 		if lastErr == nil {
 			return result, nil
 		}
+
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("request cancelled after %d attempts: %w", attempt+1, ctx.Err())
+		}
+
+		// Don't retry errors that would fail the same way again
+		if !isRetryable(lastErr) {
+			return nil, lastErr
+		}
 	}
 
 	return nil, fmt.Errorf("failed after %d attempts: %w", c.retries+1, lastErr)
 }
 
 // doRequest performs the actual HTTP request
-func (c *Client) doRequest(req Request) (interface{}, error) {
+func (c *Client) doRequest(ctx context.Context, req Request) (interface{}, error) {
 	jsonData, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", c.config.ConnectURL, bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.config.ConnectURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -118,12 +140,12 @@ func (c *Client) doRequest(req Request) (interface{}, error) {
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, newTransportError(c.config.ConnectURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode}
 	}
 
 	var apiResp Response
@@ -132,7 +154,7 @@ func (c *Client) doRequest(req Request) (interface{}, error) {
 	}
 
 	if apiResp.Error != nil {
-		return nil, fmt.Errorf("API error: %s", *apiResp.Error)
+		return nil, newAPIError(req.Action, *apiResp.Error)
 	}
 
 	return apiResp.Result, nil
@@ -140,7 +162,12 @@ func (c *Client) doRequest(req Request) (interface{}, error) {
 
 // Ping checks if the Anki Connect API is available
 func (c *Client) Ping() error {
-	_, err := c.Call("version", nil)
+	return c.PingContext(context.Background())
+}
+
+// PingContext checks if the Anki Connect API is available using the given context
+func (c *Client) PingContext(ctx context.Context) error {
+	_, err := c.CallContext(ctx, "version", nil)
 	if err != nil {
 		return fmt.Errorf("failed to connect to Anki: %w", err)
 	}
@@ -1,9 +1,11 @@
 package anki
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"anki-japanese-cli/internal/config"
 )
@@ -588,4 +590,67 @@ func TestClient_CreateModel(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestClient_CallContext(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	t.Run("Cancelled context stops retries", func(t *testing.T) {
+		attempts := 0
+		ctx, cancel := context.WithCancel(context.Background())
+		mockClient := &MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				attempts++
+				cancel()
+				return nil, errors.New("connection error")
+			},
+		}
+		client := NewClientWithHTTPClient(cfg, mockClient)
+		client.SetRetryOptions(3, time.Hour)
+
+		_, err := client.CallContext(ctx, "version", nil)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("CallContext() error = %v, expected context.Canceled", err)
+		}
+		if attempts != 1 {
+			t.Errorf("CallContext() made %d attempts, expected 1", attempts)
+		}
+	})
+
+	t.Run("Deadline interrupts retry delay", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		mockClient := NewMockHTTPClient(http.StatusOK, ``, errors.New("connection error"))
+		client := NewClientWithHTTPClient(cfg, mockClient)
+		client.SetRetryOptions(3, time.Hour)
+
+		start := time.Now()
+		_, err := client.CallContext(ctx, "version", nil)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("CallContext() error = %v, expected context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("CallContext() took %v, expected to return at the deadline", elapsed)
+		}
+	})
+
+	t.Run("Request carries the context", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), struct{}{}, "marker")
+		mockClient := &MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				if req.Context().Value(struct{}{}) != "marker" {
+					t.Error("HTTP request was not built with the caller's context")
+				}
+				return NewMockHTTPClient(http.StatusOK, `{"result": 6, "error": null}`, nil).Do(req)
+			},
+		}
+		client := NewClientWithHTTPClient(cfg, mockClient)
+
+		if err := client.PingContext(ctx); err != nil {
+			t.Errorf("PingContext() error = %v", err)
+		}
+	})
+}
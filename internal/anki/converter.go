@@ -1,6 +1,7 @@
 package anki
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -161,7 +162,12 @@ func (c *Converter) ConvertToNotes(models interface{}, deckName string, tags []s
 
 // EnsureModelExists creates the model if it doesn't exist
 func (c *Converter) EnsureModelExists(modelName string, fields []string, css string, templates []CardTemplateConfig) error {
-	exists, err := c.client.ModelExists(modelName)
+	return c.EnsureModelExistsContext(context.Background(), modelName, fields, css, templates)
+}
+
+// EnsureModelExistsContext creates the model if it doesn't exist using the given context
+func (c *Converter) EnsureModelExistsContext(ctx context.Context, modelName string, fields []string, css string, templates []CardTemplateConfig) error {
+	exists, err := c.client.ModelExistsContext(ctx, modelName)
 	if err != nil {
 		return err
 	}
@@ -174,7 +180,7 @@ func (c *Converter) EnsureModelExists(modelName string, fields []string, css str
 			CardTemplates: templates,
 		}
 
-		err = c.client.CreateModel(config)
+		err = c.client.CreateModelContext(ctx, config)
 		if err != nil {
 			return err
 		}
@@ -185,34 +191,68 @@ func (c *Converter) EnsureModelExists(modelName string, fields []string, css str
 
 // CreateCardFromModel creates an Anki card from a model
 func (c *Converter) CreateCardFromModel(model interface{}, deckName string, tags []string) (int64, error) {
-	// Ensure the deck exists
-	if err := c.client.EnsureDeckExists(deckName); err != nil {
-		return 0, err
-	}
+	return c.CreateCardFromModelContext(context.Background(), model, deckName, tags)
+}
 
+// CreateCardFromModelContext creates an Anki card from a model using the given context
+func (c *Converter) CreateCardFromModelContext(ctx context.Context, model interface{}, deckName string, tags []string) (int64, error) {
 	// Convert the model to a note
 	note, err := c.ConvertToNote(model, deckName, tags)
 	if err != nil {
 		return 0, err
 	}
 
-	// Add the note
-	return c.client.AddNote(note)
+	// Ensure the deck exists and add the note in a single round trip
+	// (createDeck is a no-op for existing decks)
+	batch := c.client.NewBatch()
+	deckIdx := batch.QueueCreateDeck(deckName)
+	noteIdx := batch.QueueAddNote(note)
+
+	results, err := batch.ExecuteContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if results[deckIdx].Err != nil {
+		return 0, fmt.Errorf("failed to create deck: %w", results[deckIdx].Err)
+	}
+
+	noteID, err := results[noteIdx].Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to add note: %w", err)
+	}
+
+	return noteID, nil
 }
 
 // CreateCardsFromModels creates Anki cards from models
 func (c *Converter) CreateCardsFromModels(models interface{}, deckName string, tags []string) ([]int64, error) {
-	// Ensure the deck exists
-	if err := c.client.EnsureDeckExists(deckName); err != nil {
-		return nil, err
-	}
+	return c.CreateCardsFromModelsContext(context.Background(), models, deckName, tags)
+}
 
+// CreateCardsFromModelsContext creates Anki cards from models using the given context
+func (c *Converter) CreateCardsFromModelsContext(ctx context.Context, models interface{}, deckName string, tags []string) ([]int64, error) {
 	// Convert the models to notes
 	notes, err := c.ConvertToNotes(models, deckName, tags)
 	if err != nil {
 		return nil, err
 	}
 
-	// Add the notes
-	return c.client.AddNotes(notes)
+	// Ensure the deck exists and add the notes in a single round trip
+	// (createDeck is a no-op for existing decks)
+	batch := c.client.NewBatch()
+	deckIdx := batch.QueueCreateDeck(deckName)
+	notesIdx := batch.QueueAddNotes(notes)
+
+	results, err := batch.ExecuteContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if results[deckIdx].Err != nil {
+		return nil, fmt.Errorf("failed to create deck: %w", results[deckIdx].Err)
+	}
+	if results[notesIdx].Err != nil {
+		return nil, fmt.Errorf("failed to add notes: %w", results[notesIdx].Err)
+	}
+
+	return results[notesIdx].NoteIDs()
 }
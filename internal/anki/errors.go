@@ -0,0 +1,159 @@
+package anki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+)
+
+// ConnectionRefusedError is returned when nothing is listening on the Anki Connect URL,
+// usually because Anki is not running or the AnkiConnect add-on is not installed
+type ConnectionRefusedError struct {
+	URL string
+	Err error
+}
+
+// Error implements the error interface
+func (e *ConnectionRefusedError) Error() string {
+	return fmt.Sprintf("connection refused by %s: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the underlying transport error
+func (e *ConnectionRefusedError) Unwrap() error {
+	return e.Err
+}
+
+// TimeoutError is returned when Anki Connect does not answer in time
+type TimeoutError struct {
+	URL string
+	Err error
+}
+
+// Error implements the error interface
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout waiting for %s: %v", e.URL, e.Err)
+}
+
+// Unwrap returns the underlying transport error
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusError is returned when Anki Connect answers with a non-200 status code
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+// Error implements the error interface
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// APIError is returned when Anki Connect reports an error for an action.
+// Message holds the raw error string sent by AnkiConnect.
+type APIError struct {
+	Action  string
+	Message string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: %s", e.Message)
+}
+
+// DuplicateNoteError is returned when a note cannot be created because it is a duplicate
+type DuplicateNoteError struct {
+	*APIError
+}
+
+// Unwrap returns the underlying API error
+func (e *DuplicateNoteError) Unwrap() error {
+	return e.APIError
+}
+
+// ModelNotFoundError is returned when the requested note type does not exist
+type ModelNotFoundError struct {
+	*APIError
+	ModelName string
+}
+
+// Unwrap returns the underlying API error
+func (e *ModelNotFoundError) Unwrap() error {
+	return e.APIError
+}
+
+// DeckNotFoundError is returned when the requested deck does not exist
+type DeckNotFoundError struct {
+	*APIError
+	DeckName string
+}
+
+// Unwrap returns the underlying API error
+func (e *DeckNotFoundError) Unwrap() error {
+	return e.APIError
+}
+
+// newAPIError classifies a raw AnkiConnect error message into a typed error
+func newAPIError(action, message string) error {
+	apiErr := &APIError{Action: action, Message: message}
+
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "duplicate"):
+		return &DuplicateNoteError{APIError: apiErr}
+	case strings.Contains(lower, "model was not found"):
+		return &ModelNotFoundError{APIError: apiErr, ModelName: nameAfterColon(message)}
+	case strings.Contains(lower, "deck was not found"):
+		return &DeckNotFoundError{APIError: apiErr, DeckName: nameAfterColon(message)}
+	}
+
+	return apiErr
+}
+
+// nameAfterColon extracts the name AnkiConnect appends to "... was not found: <name>" messages
+func nameAfterColon(message string) string {
+	idx := strings.LastIndex(message, ":")
+	if idx < 0 {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(message[idx+1:]), "'\"[]")
+}
+
+// newTransportError classifies an error returned by the HTTP client
+func newTransportError(url string, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return &ConnectionRefusedError{URL: url, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return &TimeoutError{URL: url, Err: err}
+	}
+
+	return fmt.Errorf("failed to send request: %w", err)
+}
+
+// isRetryable reports whether a failed request is worth retrying.
+// Errors reported by AnkiConnect itself and client-side HTTP errors are deterministic
+// and would fail the same way again.
+func isRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
@@ -0,0 +1,176 @@
+package anki
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"anki-japanese-cli/internal/config"
+)
+
+func TestClient_CallErrorTypes(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+
+	tests := []struct {
+		name           string
+		mockStatus     int
+		mockBody       string
+		mockErr        error
+		expectAttempts int
+		check          func(err error) bool
+	}{
+		{
+			name:           "Duplicate note is not retried",
+			mockStatus:     http.StatusOK,
+			mockBody:       `{"result": null, "error": "cannot create note because it is a duplicate"}`,
+			expectAttempts: 1,
+			check: func(err error) bool {
+				var dupErr *DuplicateNoteError
+				var apiErr *APIError
+				return errors.As(err, &dupErr) && errors.As(err, &apiErr) &&
+					apiErr.Message == "cannot create note because it is a duplicate"
+			},
+		},
+		{
+			name:           "Model not found",
+			mockStatus:     http.StatusOK,
+			mockBody:       `{"result": null, "error": "model was not found: Japanese Verb"}`,
+			expectAttempts: 1,
+			check: func(err error) bool {
+				var modelErr *ModelNotFoundError
+				return errors.As(err, &modelErr) && modelErr.ModelName == "Japanese Verb"
+			},
+		},
+		{
+			name:           "Deck not found",
+			mockStatus:     http.StatusOK,
+			mockBody:       `{"result": null, "error": "deck was not found: 日文動詞"}`,
+			expectAttempts: 1,
+			check: func(err error) bool {
+				var deckErr *DeckNotFoundError
+				return errors.As(err, &deckErr) && deckErr.DeckName == "日文動詞"
+			},
+		},
+		{
+			name:           "Generic API error",
+			mockStatus:     http.StatusOK,
+			mockBody:       `{"result": null, "error": "unsupported action"}`,
+			expectAttempts: 1,
+			check: func(err error) bool {
+				var apiErr *APIError
+				return errors.As(err, &apiErr) && apiErr.Action == "version"
+			},
+		},
+		{
+			name:           "Server error is retried",
+			mockStatus:     http.StatusInternalServerError,
+			expectAttempts: 3,
+			check: func(err error) bool {
+				var statusErr *HTTPStatusError
+				return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusInternalServerError
+			},
+		},
+		{
+			name:           "Client error is not retried",
+			mockStatus:     http.StatusNotFound,
+			expectAttempts: 1,
+			check: func(err error) bool {
+				var statusErr *HTTPStatusError
+				return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+			},
+		},
+		{
+			name:           "Connection refused is retried",
+			mockErr:        refused,
+			expectAttempts: 3,
+			check: func(err error) bool {
+				var refusedErr *ConnectionRefusedError
+				return errors.As(err, &refusedErr) && errors.Is(err, syscall.ECONNREFUSED)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			mock := NewMockHTTPClient(tt.mockStatus, tt.mockBody, tt.mockErr)
+			client := NewClientWithHTTPClient(cfg, &MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return mock.Do(req)
+				},
+			})
+			client.SetRetryOptions(2, time.Millisecond)
+
+			_, err := client.Call("version", nil)
+			if err == nil {
+				t.Fatal("Call() expected error, got nil")
+			}
+			if !tt.check(err) {
+				t.Errorf("Call() error = %v (%T), not of the expected kind", err, err)
+			}
+			if attempts != tt.expectAttempts {
+				t.Errorf("Call() made %d attempts, expected %d", attempts, tt.expectAttempts)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		contains string
+	}{
+		{
+			name:     "Nil error",
+			err:      nil,
+			contains: "",
+		},
+		{
+			name:     "Connection refused",
+			err:      &ConnectionRefusedError{URL: "http://localhost:8765", Err: syscall.ECONNREFUSED},
+			contains: "無法連線到 Anki",
+		},
+		{
+			name:     "Timeout",
+			err:      &TimeoutError{URL: "http://localhost:8765", Err: errors.New("i/o timeout")},
+			contains: "逾時",
+		},
+		{
+			name:     "Duplicate note",
+			err:      newAPIError("addNote", "cannot create note because it is a duplicate"),
+			contains: "卡片重複",
+		},
+		{
+			name:     "Model not found",
+			err:      newAPIError("addNote", "model was not found: Japanese Verb"),
+			contains: "Japanese Verb",
+		},
+		{
+			name:     "Wrapped API error",
+			err:      fmt.Errorf("failed to add note: %w", newAPIError("addNote", "collection is not available")),
+			contains: "Anki API 錯誤: collection is not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := FormatError(tt.err)
+			if !strings.Contains(msg, tt.contains) {
+				t.Errorf("FormatError() = %q, expected to contain %q", msg, tt.contains)
+			}
+		})
+	}
+}
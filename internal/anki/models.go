@@ -1,6 +1,7 @@
 package anki
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -20,8 +21,8 @@ type CardTemplateConfig struct {
 	Back  string `json:"Back"`
 }
 
-// CreateModel creates a new note model
-func (c *Client) CreateModel(config ModelConfig) error {
+// modelParams converts a model configuration to the parameters of a createModel request
+func modelParams(config ModelConfig) map[string]interface{} {
 	params := map[string]interface{}{
 		"modelName":     config.ModelName,
 		"inOrderFields": config.InOrderFields,
@@ -33,7 +34,17 @@ func (c *Client) CreateModel(config ModelConfig) error {
 		params["isCloze"] = true
 	}
 
-	_, err := c.Call("createModel", params)
+	return params
+}
+
+// CreateModel creates a new note model
+func (c *Client) CreateModel(config ModelConfig) error {
+	return c.CreateModelContext(context.Background(), config)
+}
+
+// CreateModelContext creates a new note model using the given context
+func (c *Client) CreateModelContext(ctx context.Context, config ModelConfig) error {
+	_, err := c.CallContext(ctx, "createModel", modelParams(config))
 	if err != nil {
 		return fmt.Errorf("failed to create model: %w", err)
 	}
@@ -41,9 +52,19 @@ func (c *Client) CreateModel(config ModelConfig) error {
 	return nil
 }
 
+// QueueCreateModel queues a createModel action in the batch
+func (b *Batch) QueueCreateModel(config ModelConfig) int {
+	return b.Add("createModel", modelParams(config))
+}
+
 // ModelNames returns a list of all model names
 func (c *Client) ModelNames() ([]string, error) {
-	result, err := c.Call("modelNames", nil)
+	return c.ModelNamesContext(context.Background())
+}
+
+// ModelNamesContext returns a list of all model names using the given context
+func (c *Client) ModelNamesContext(ctx context.Context) ([]string, error) {
+	result, err := c.CallContext(ctx, "modelNames", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get model names: %w", err)
 	}
@@ -68,11 +89,16 @@ func (c *Client) ModelNames() ([]string, error) {
 
 // ModelFieldNames returns a list of field names for the specified model
 func (c *Client) ModelFieldNames(modelName string) ([]string, error) {
+	return c.ModelFieldNamesContext(context.Background(), modelName)
+}
+
+// ModelFieldNamesContext returns a list of field names for the specified model using the given context
+func (c *Client) ModelFieldNamesContext(ctx context.Context, modelName string) ([]string, error) {
 	params := map[string]interface{}{
 		"modelName": modelName,
 	}
 
-	result, err := c.Call("modelFieldNames", params)
+	result, err := c.CallContext(ctx, "modelFieldNames", params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get model field names: %w", err)
 	}
@@ -95,8 +121,19 @@ func (c *Client) ModelFieldNames(modelName string) ([]string, error) {
 	return fieldNames, nil
 }
 
+// QueueModelFieldNames queues a modelFieldNames action in the batch.
+// Use BatchResult.Strings to decode its result.
+func (b *Batch) QueueModelFieldNames(modelName string) int {
+	return b.Add("modelFieldNames", map[string]interface{}{"modelName": modelName})
+}
+
 // UpdateModelTemplates updates the templates for the specified model
 func (c *Client) UpdateModelTemplates(modelName string, templates map[string]map[string]string) error {
+	return c.UpdateModelTemplatesContext(context.Background(), modelName, templates)
+}
+
+// UpdateModelTemplatesContext updates the templates for the specified model using the given context
+func (c *Client) UpdateModelTemplatesContext(ctx context.Context, modelName string, templates map[string]map[string]string) error {
 	params := map[string]interface{}{
 		"model": map[string]interface{}{
 			"name":      modelName,
@@ -104,7 +141,7 @@ func (c *Client) UpdateModelTemplates(modelName string, templates map[string]map
 		},
 	}
 
-	_, err := c.Call("updateModelTemplates", params)
+	_, err := c.CallContext(ctx, "updateModelTemplates", params)
 	if err != nil {
 		return fmt.Errorf("failed to update model templates: %w", err)
 	}
@@ -112,9 +149,198 @@ func (c *Client) UpdateModelTemplates(modelName string, templates map[string]map
 	return nil
 }
 
+// QueueUpdateModelTemplates queues an updateModelTemplates action in the batch
+func (b *Batch) QueueUpdateModelTemplates(modelName string, templates map[string]map[string]string) int {
+	return b.Add("updateModelTemplates", map[string]interface{}{
+		"model": map[string]interface{}{
+			"name":      modelName,
+			"templates": templates,
+		},
+	})
+}
+
+// ModelTemplates returns the front and back template of each card type of the specified model
+func (c *Client) ModelTemplates(modelName string) (map[string]map[string]string, error) {
+	return c.ModelTemplatesContext(context.Background(), modelName)
+}
+
+// ModelTemplatesContext returns the front and back template of each card type of the specified model using the given context
+func (c *Client) ModelTemplatesContext(ctx context.Context, modelName string) (map[string]map[string]string, error) {
+	result, err := c.CallContext(ctx, "modelTemplates", map[string]interface{}{"modelName": modelName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get model templates: %w", err)
+	}
+
+	var templates map[string]map[string]string
+	if err := decodeResult(result, &templates); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// QueueModelTemplates queues a modelTemplates action in the batch.
+// Use BatchResult.Decode to decode its result into a map[string]map[string]string.
+func (b *Batch) QueueModelTemplates(modelName string) int {
+	return b.Add("modelTemplates", map[string]interface{}{"modelName": modelName})
+}
+
+// ModelStyling returns the CSS of the specified model
+func (c *Client) ModelStyling(modelName string) (string, error) {
+	return c.ModelStylingContext(context.Background(), modelName)
+}
+
+// ModelStylingContext returns the CSS of the specified model using the given context
+func (c *Client) ModelStylingContext(ctx context.Context, modelName string) (string, error) {
+	result, err := c.CallContext(ctx, "modelStyling", map[string]interface{}{"modelName": modelName})
+	if err != nil {
+		return "", fmt.Errorf("failed to get model styling: %w", err)
+	}
+
+	return parseStyling(result)
+}
+
+// QueueModelStyling queues a modelStyling action in the batch.
+// Use BatchResult.Styling to decode its result.
+func (b *Batch) QueueModelStyling(modelName string) int {
+	return b.Add("modelStyling", map[string]interface{}{"modelName": modelName})
+}
+
+// parseStyling extracts the CSS from a modelStyling result
+func parseStyling(result interface{}) (string, error) {
+	var styling struct {
+		CSS string `json:"css"`
+	}
+	if err := decodeResult(result, &styling); err != nil {
+		return "", err
+	}
+	return styling.CSS, nil
+}
+
+// UpdateModelStyling replaces the CSS of the specified model
+func (c *Client) UpdateModelStyling(modelName, css string) error {
+	return c.UpdateModelStylingContext(context.Background(), modelName, css)
+}
+
+// UpdateModelStylingContext replaces the CSS of the specified model using the given context
+func (c *Client) UpdateModelStylingContext(ctx context.Context, modelName, css string) error {
+	_, err := c.CallContext(ctx, "updateModelStyling", updateModelStylingParams(modelName, css))
+	if err != nil {
+		return fmt.Errorf("failed to update model styling: %w", err)
+	}
+
+	return nil
+}
+
+// QueueUpdateModelStyling queues an updateModelStyling action in the batch
+func (b *Batch) QueueUpdateModelStyling(modelName, css string) int {
+	return b.Add("updateModelStyling", updateModelStylingParams(modelName, css))
+}
+
+// updateModelStylingParams builds the parameters of an updateModelStyling request
+func updateModelStylingParams(modelName, css string) map[string]interface{} {
+	return map[string]interface{}{
+		"model": map[string]interface{}{
+			"name": modelName,
+			"css":  css,
+		},
+	}
+}
+
+// ModelFieldAdd adds a field to the specified model at the given position (0-based)
+func (c *Client) ModelFieldAdd(modelName, fieldName string, index int) error {
+	return c.ModelFieldAddContext(context.Background(), modelName, fieldName, index)
+}
+
+// ModelFieldAddContext adds a field to the specified model at the given position using the given context
+func (c *Client) ModelFieldAddContext(ctx context.Context, modelName, fieldName string, index int) error {
+	_, err := c.CallContext(ctx, "modelFieldAdd", modelFieldAddParams(modelName, fieldName, index))
+	if err != nil {
+		return fmt.Errorf("failed to add model field: %w", err)
+	}
+
+	return nil
+}
+
+// QueueModelFieldAdd queues a modelFieldAdd action in the batch
+func (b *Batch) QueueModelFieldAdd(modelName, fieldName string, index int) int {
+	return b.Add("modelFieldAdd", modelFieldAddParams(modelName, fieldName, index))
+}
+
+// modelFieldAddParams builds the parameters of a modelFieldAdd request
+func modelFieldAddParams(modelName, fieldName string, index int) map[string]interface{} {
+	return map[string]interface{}{
+		"modelName": modelName,
+		"fieldName": fieldName,
+		"index":     index,
+	}
+}
+
+// ModelFieldRename renames a field of the specified model, keeping its contents
+func (c *Client) ModelFieldRename(modelName, oldFieldName, newFieldName string) error {
+	return c.ModelFieldRenameContext(context.Background(), modelName, oldFieldName, newFieldName)
+}
+
+// ModelFieldRenameContext renames a field of the specified model using the given context
+func (c *Client) ModelFieldRenameContext(ctx context.Context, modelName, oldFieldName, newFieldName string) error {
+	_, err := c.CallContext(ctx, "modelFieldRename", modelFieldRenameParams(modelName, oldFieldName, newFieldName))
+	if err != nil {
+		return fmt.Errorf("failed to rename model field: %w", err)
+	}
+
+	return nil
+}
+
+// QueueModelFieldRename queues a modelFieldRename action in the batch
+func (b *Batch) QueueModelFieldRename(modelName, oldFieldName, newFieldName string) int {
+	return b.Add("modelFieldRename", modelFieldRenameParams(modelName, oldFieldName, newFieldName))
+}
+
+// modelFieldRenameParams builds the parameters of a modelFieldRename request
+func modelFieldRenameParams(modelName, oldFieldName, newFieldName string) map[string]interface{} {
+	return map[string]interface{}{
+		"modelName":    modelName,
+		"oldFieldName": oldFieldName,
+		"newFieldName": newFieldName,
+	}
+}
+
+// ModelTemplateAdd adds a card template to the specified model
+func (c *Client) ModelTemplateAdd(modelName string, template CardTemplateConfig) error {
+	return c.ModelTemplateAddContext(context.Background(), modelName, template)
+}
+
+// ModelTemplateAddContext adds a card template to the specified model using the given context
+func (c *Client) ModelTemplateAddContext(ctx context.Context, modelName string, template CardTemplateConfig) error {
+	_, err := c.CallContext(ctx, "modelTemplateAdd", modelTemplateAddParams(modelName, template))
+	if err != nil {
+		return fmt.Errorf("failed to add model template: %w", err)
+	}
+
+	return nil
+}
+
+// QueueModelTemplateAdd queues a modelTemplateAdd action in the batch
+func (b *Batch) QueueModelTemplateAdd(modelName string, template CardTemplateConfig) int {
+	return b.Add("modelTemplateAdd", modelTemplateAddParams(modelName, template))
+}
+
+// modelTemplateAddParams builds the parameters of a modelTemplateAdd request
+func modelTemplateAddParams(modelName string, template CardTemplateConfig) map[string]interface{} {
+	return map[string]interface{}{
+		"modelName": modelName,
+		"template":  template,
+	}
+}
+
 // ModelExists checks if a model with the given name exists
 func (c *Client) ModelExists(modelName string) (bool, error) {
-	names, err := c.ModelNames()
+	return c.ModelExistsContext(context.Background(), modelName)
+}
+
+// ModelExistsContext checks if a model with the given name exists using the given context
+func (c *Client) ModelExistsContext(ctx context.Context, modelName string) (bool, error) {
+	names, err := c.ModelNamesContext(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,177 @@
+package anki
+
+import (
+	"context"
+	"fmt"
+)
+
+// BatchResult holds the outcome of a single action sent through a multi request
+type BatchResult struct {
+	Action string
+	Result interface{}
+	Err    error
+}
+
+// Strings converts the result to a string slice
+func (r BatchResult) Strings() ([]string, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	items, ok := r.Result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type for %s: %T", r.Action, r.Result)
+	}
+
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected item type for %s: %T", r.Action, item)
+		}
+		values = append(values, str)
+	}
+
+	return values, nil
+}
+
+// Int64 converts the result to an int64 (e.g. a deck or note ID)
+func (r BatchResult) Int64() (int64, error) {
+	if r.Err != nil {
+		return 0, r.Err
+	}
+
+	value, ok := r.Result.(float64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result type for %s: %T", r.Action, r.Result)
+	}
+
+	return int64(value), nil
+}
+
+// NoteIDs converts an addNotes or findNotes result to note IDs (0 for notes that were not added)
+func (r BatchResult) NoteIDs() ([]int64, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return parseNoteIDs(r.Result)
+}
+
+// Bools converts a canAddNotes result to a bool slice
+func (r BatchResult) Bools() ([]bool, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return parseBools(r.Result)
+}
+
+// Decode converts the result into the given typed value (e.g. a modelTemplates result)
+func (r BatchResult) Decode(v interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return decodeResult(r.Result, v)
+}
+
+// Styling converts a modelStyling result to its CSS
+func (r BatchResult) Styling() (string, error) {
+	if r.Err != nil {
+		return "", r.Err
+	}
+	return parseStyling(r.Result)
+}
+
+// Batch queues several Anki Connect actions to be sent in a single multi request
+type Batch struct {
+	client  *Client
+	actions []Request
+}
+
+// NewBatch creates an empty batch bound to the client
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// Add queues an action and returns its index in the batch results
+func (b *Batch) Add(action string, params interface{}) int {
+	b.actions = append(b.actions, Request{
+		Action:  action,
+		Version: APIVersion,
+		Params:  params,
+	})
+	return len(b.actions) - 1
+}
+
+// Len returns the number of queued actions
+func (b *Batch) Len() int {
+	return len(b.actions)
+}
+
+// Execute sends all queued actions in one request
+func (b *Batch) Execute() ([]BatchResult, error) {
+	return b.ExecuteContext(context.Background())
+}
+
+// ExecuteContext sends all queued actions in one request using the given context
+func (b *Batch) ExecuteContext(ctx context.Context) ([]BatchResult, error) {
+	return b.client.MultiContext(ctx, b.actions)
+}
+
+// Multi performs multiple actions in one request and returns the result of each action in order
+func (c *Client) Multi(actions []Request) ([]BatchResult, error) {
+	return c.MultiContext(context.Background(), actions)
+}
+
+// MultiContext performs multiple actions in one request using the given context.
+// The returned error is only set when the request as a whole fails; failures of
+// individual actions are reported in the Err field of their BatchResult.
+func (c *Client) MultiContext(ctx context.Context, actions []Request) ([]BatchResult, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	// Ask for the version 6 response format ({"result": ..., "error": ...}) for every action
+	for i := range actions {
+		if actions[i].Version == 0 {
+			actions[i].Version = APIVersion
+		}
+	}
+
+	params := map[string]interface{}{
+		"actions": actions,
+	}
+
+	result, err := c.CallContext(ctx, "multi", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform multi request: %w", err)
+	}
+
+	responses, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type: %T", result)
+	}
+
+	if len(responses) != len(actions) {
+		return nil, fmt.Errorf("unexpected number of results: got %d, expected %d", len(responses), len(actions))
+	}
+
+	results := make([]BatchResult, len(actions))
+	for i, response := range responses {
+		results[i].Action = actions[i].Action
+
+		responseMap, ok := response.(map[string]interface{})
+		if !ok {
+			results[i].Err = fmt.Errorf("unexpected response type for %s: %T", actions[i].Action, response)
+			continue
+		}
+
+		if errMsg, ok := responseMap["error"].(string); ok {
+			results[i].Err = newAPIError(actions[i].Action, errMsg)
+			continue
+		}
+
+		results[i].Result = responseMap["result"]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,290 @@
+package anki
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"anki-japanese-cli/internal/config"
+)
+
+func TestBatch_Execute(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	requestCount := 0
+	mockClient := NewMockHTTPClientWithRequestCheck(
+		http.StatusOK,
+		`{"result": [
+			{"result": ["Default", "日文動詞"], "error": null},
+			{"result": 1234, "error": null},
+			{"result": [5678, null], "error": null},
+			{"result": null, "error": "model was not found: Missing"}
+		], "error": null}`,
+		nil,
+		func(req *http.Request) bool {
+			requestCount++
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return false
+			}
+
+			var request struct {
+				Action string `json:"action"`
+				Params struct {
+					Actions []Request `json:"actions"`
+				} `json:"params"`
+			}
+			if err := json.Unmarshal(body, &request); err != nil {
+				return false
+			}
+
+			if request.Action != "multi" || len(request.Params.Actions) != 4 {
+				return false
+			}
+			for _, action := range request.Params.Actions {
+				if action.Version != APIVersion {
+					return false
+				}
+			}
+			return request.Params.Actions[2].Action == "addNotes"
+		},
+	)
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	batch := client.NewBatch()
+	decksIdx := batch.Add("deckNames", nil)
+	deckIdx := batch.QueueCreateDeck("日文動詞")
+	notesIdx := batch.QueueAddNotes([]NoteInfo{
+		{DeckName: "日文動詞", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": "飲む"}},
+		{DeckName: "日文動詞", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": "食べる"}},
+	})
+	modelIdx := batch.Add("modelFieldNames", map[string]interface{}{"modelName": "Missing"})
+
+	if batch.Len() != 4 {
+		t.Fatalf("Len() = %d, expected 4", batch.Len())
+	}
+
+	results, err := batch.Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if requestCount != 1 {
+		t.Errorf("Execute() sent %d requests, expected 1", requestCount)
+	}
+
+	decks, err := results[decksIdx].Strings()
+	if err != nil || len(decks) != 2 || decks[1] != "日文動詞" {
+		t.Errorf("deckNames result = %v, %v", decks, err)
+	}
+
+	deckID, err := results[deckIdx].Int64()
+	if err != nil || deckID != 1234 {
+		t.Errorf("createDeck result = %v, %v", deckID, err)
+	}
+
+	noteIDs, err := results[notesIdx].NoteIDs()
+	if err != nil || len(noteIDs) != 2 || noteIDs[0] != 5678 || noteIDs[1] != 0 {
+		t.Errorf("addNotes result = %v, %v", noteIDs, err)
+	}
+
+	var modelErr *ModelNotFoundError
+	if !errors.As(results[modelIdx].Err, &modelErr) {
+		t.Errorf("modelFieldNames error = %v, expected ModelNotFoundError", results[modelIdx].Err)
+	}
+}
+
+func TestClient_Multi(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	tests := []struct {
+		name        string
+		actions     []Request
+		mockBody    string
+		mockErr     error
+		expectError bool
+		expectLen   int
+	}{
+		{
+			name:      "Empty batch",
+			actions:   nil,
+			expectLen: 0,
+		},
+		{
+			name:      "Single action",
+			actions:   []Request{{Action: "version"}},
+			mockBody:  `{"result": [{"result": 6, "error": null}], "error": null}`,
+			expectLen: 1,
+		},
+		{
+			name:        "Result count mismatch",
+			actions:     []Request{{Action: "version"}, {Action: "deckNames"}},
+			mockBody:    `{"result": [{"result": 6, "error": null}], "error": null}`,
+			expectError: true,
+		},
+		{
+			name:        "Whole request fails",
+			actions:     []Request{{Action: "version"}},
+			mockBody:    `{"result": null, "error": "unsupported action"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := NewMockHTTPClient(http.StatusOK, tt.mockBody, tt.mockErr)
+			client := NewClientWithHTTPClient(cfg, mockClient)
+
+			results, err := client.Multi(tt.actions)
+			if (err != nil) != tt.expectError {
+				t.Errorf("Multi() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !tt.expectError && len(results) != tt.expectLen {
+				t.Errorf("Multi() returned %d results, expected %d", len(results), tt.expectLen)
+			}
+		})
+	}
+}
+
+func TestClient_AddNotesWithResults(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+	mockBody := `{"result": [
+		{"result": 1496198395707, "error": null},
+		{"result": null, "error": "cannot create note because it is a duplicate"},
+		{"result": null, "error": "cannot create note because it is empty"}
+	], "error": null}`
+
+	mockClient := NewMockHTTPClient(http.StatusOK, mockBody, nil)
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	notes := []NoteInfo{
+		{DeckName: "test", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": "食べる"}},
+		{DeckName: "test", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": "飲む"}},
+		{DeckName: "test", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": ""}},
+	}
+
+	results, err := client.AddNotesWithResults(notes)
+	if err != nil {
+		t.Fatalf("AddNotesWithResults() error = %v", err)
+	}
+	if len(results) != len(notes) {
+		t.Fatalf("AddNotesWithResults() returned %d results, expected %d", len(results), len(notes))
+	}
+
+	if results[0].NoteID != 1496198395707 || results[0].Err != nil {
+		t.Errorf("results[0] = %+v, expected note ID 1496198395707", results[0])
+	}
+
+	var dupErr *DuplicateNoteError
+	if results[1].NoteID != 0 || !errors.As(results[1].Err, &dupErr) {
+		t.Errorf("results[1].Err = %v, expected DuplicateNoteError", results[1].Err)
+	}
+	if results[1].Index != 1 || results[1].Note.Fields["核心單字"] != "飲む" {
+		t.Errorf("results[1] does not point back to its note: %+v", results[1])
+	}
+
+	if results[2].Err == nil {
+		t.Error("results[2].Err = nil, expected an error")
+	}
+}
+
+func TestBatch_ModelUpgrade(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	var actions []string
+	mockClient := NewMockHTTPClientWithRequestCheck(
+		http.StatusOK,
+		`{"result": [
+			{"result": {"Japanese Verb": {"Front": "{{核心單字}}", "Back": "{{發音}}"}}, "error": null},
+			{"result": {"css": ".card { color: black; }"}, "error": null},
+			{"result": null, "error": null},
+			{"result": null, "error": null},
+			{"result": null, "error": null},
+			{"result": null, "error": "field already exists"},
+			{"result": null, "error": null}
+		], "error": null}`,
+		nil,
+		func(req *http.Request) bool {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return false
+			}
+
+			var request struct {
+				Params struct {
+					Actions []Request `json:"actions"`
+				} `json:"params"`
+			}
+			if err := json.Unmarshal(body, &request); err != nil {
+				return false
+			}
+			for _, action := range request.Params.Actions {
+				actions = append(actions, action.Action)
+			}
+			return true
+		},
+	)
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	batch := client.NewBatch()
+	templatesIdx := batch.QueueModelTemplates("Japanese Verb")
+	stylingIdx := batch.QueueModelStyling("Japanese Verb")
+	batch.QueueUpdateModelTemplates("Japanese Verb", map[string]map[string]string{
+		"Japanese Verb": {"Front": "{{核心單字}}", "Back": "{{發音}}{{重音}}"},
+	})
+	batch.QueueUpdateModelStyling("Japanese Verb", ".card { color: white; }")
+	batch.QueueModelFieldRename("Japanese Verb", "詞性", "詞性分類")
+	addIdx := batch.QueueModelFieldAdd("Japanese Verb", "圖片提示", 9)
+	templateIdx := batch.QueueModelTemplateAdd("Japanese Verb", CardTemplateConfig{Name: "讀音", Front: "{{核心單字}}", Back: "{{發音}}"})
+
+	results, err := batch.Execute()
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	expectedActions := []string{"modelTemplates", "modelStyling", "updateModelTemplates", "updateModelStyling", "modelFieldRename", "modelFieldAdd", "modelTemplateAdd"}
+	if len(actions) != len(expectedActions) {
+		t.Fatalf("sent actions %v, expected %v", actions, expectedActions)
+	}
+	for i, action := range expectedActions {
+		if actions[i] != action {
+			t.Errorf("action %d = %s, expected %s", i, actions[i], action)
+		}
+	}
+
+	var templates map[string]map[string]string
+	if err := results[templatesIdx].Decode(&templates); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if templates["Japanese Verb"]["Back"] != "{{發音}}" {
+		t.Errorf("Decode() = %v, unexpected back template", templates)
+	}
+
+	css, err := results[stylingIdx].Styling()
+	if err != nil {
+		t.Fatalf("Styling() error = %v", err)
+	}
+	if css != ".card { color: black; }" {
+		t.Errorf("Styling() = %q", css)
+	}
+
+	if results[addIdx].Err == nil {
+		t.Error("expected modelFieldAdd to report its error")
+	}
+	if results[templateIdx].Err != nil {
+		t.Errorf("modelTemplateAdd error = %v", results[templateIdx].Err)
+	}
+}
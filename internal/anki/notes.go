@@ -0,0 +1,207 @@
+package anki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NoteField represents a single field of a note stored in Anki
+type NoteField struct {
+	Value string `json:"value"`
+	Order int    `json:"order"`
+}
+
+// Note represents a note stored in Anki, as returned by notesInfo
+type Note struct {
+	NoteID    int64                `json:"noteId"`
+	ModelName string               `json:"modelName"`
+	Tags      []string             `json:"tags"`
+	Fields    map[string]NoteField `json:"fields"`
+	Mod       int64                `json:"mod"`
+	Cards     []int64              `json:"cards"`
+}
+
+// FieldValues returns the note fields as a name to value map
+func (n Note) FieldValues() map[string]string {
+	values := make(map[string]string, len(n.Fields))
+	for name, field := range n.Fields {
+		values[name] = field.Value
+	}
+	return values
+}
+
+// decodeResult converts a generic API result into the given typed value
+func decodeResult(result interface{}, v interface{}) error {
+	jsonData, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+	if err := json.Unmarshal(jsonData, v); err != nil {
+		return fmt.Errorf("unexpected result format: %w", err)
+	}
+	return nil
+}
+
+// FindNotes returns the IDs of the notes matching an Anki search query
+func (c *Client) FindNotes(query string) ([]int64, error) {
+	return c.FindNotesContext(context.Background(), query)
+}
+
+// FindNotesContext returns the IDs of the notes matching an Anki search query using the given context
+func (c *Client) FindNotesContext(ctx context.Context, query string) ([]int64, error) {
+	params := map[string]interface{}{
+		"query": query,
+	}
+
+	result, err := c.CallContext(ctx, "findNotes", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notes: %w", err)
+	}
+
+	var noteIDs []int64
+	if err := decodeResult(result, &noteIDs); err != nil {
+		return nil, err
+	}
+
+	return noteIDs, nil
+}
+
+// QueueFindNotes queues a findNotes action in the batch.
+// Use BatchResult.NoteIDs to decode its result.
+func (b *Batch) QueueFindNotes(query string) int {
+	return b.Add("findNotes", map[string]interface{}{"query": query})
+}
+
+// NotesInfo returns the fields, tags and model of the given notes
+func (c *Client) NotesInfo(noteIDs []int64) ([]Note, error) {
+	return c.NotesInfoContext(context.Background(), noteIDs)
+}
+
+// NotesInfoContext returns the fields, tags and model of the given notes using the given context
+func (c *Client) NotesInfoContext(ctx context.Context, noteIDs []int64) ([]Note, error) {
+	if len(noteIDs) == 0 {
+		return nil, nil
+	}
+
+	params := map[string]interface{}{
+		"notes": noteIDs,
+	}
+
+	result, err := c.CallContext(ctx, "notesInfo", params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes info: %w", err)
+	}
+
+	// notesInfo returns an empty object for IDs that no longer exist
+	var rawNotes []json.RawMessage
+	if err := decodeResult(result, &rawNotes); err != nil {
+		return nil, err
+	}
+
+	var notes []Note
+	for _, raw := range rawNotes {
+		var note Note
+		if err := json.Unmarshal(raw, &note); err != nil {
+			return nil, fmt.Errorf("unexpected note format: %w", err)
+		}
+		if note.NoteID == 0 {
+			continue
+		}
+		notes = append(notes, note)
+	}
+
+	return notes, nil
+}
+
+// UpdateNoteFields sets the given fields of an existing note; fields not listed are left unchanged
+func (c *Client) UpdateNoteFields(noteID int64, fields map[string]string) error {
+	return c.UpdateNoteFieldsContext(context.Background(), noteID, fields)
+}
+
+// UpdateNoteFieldsContext sets the given fields of an existing note using the given context
+func (c *Client) UpdateNoteFieldsContext(ctx context.Context, noteID int64, fields map[string]string) error {
+	_, err := c.CallContext(ctx, "updateNoteFields", updateNoteFieldsParams(noteID, fields))
+	if err != nil {
+		return fmt.Errorf("failed to update note fields: %w", err)
+	}
+
+	return nil
+}
+
+// QueueUpdateNoteFields queues an updateNoteFields action in the batch
+func (b *Batch) QueueUpdateNoteFields(noteID int64, fields map[string]string) int {
+	return b.Add("updateNoteFields", updateNoteFieldsParams(noteID, fields))
+}
+
+// updateNoteFieldsParams builds the parameters of an updateNoteFields request
+func updateNoteFieldsParams(noteID int64, fields map[string]string) map[string]interface{} {
+	return map[string]interface{}{
+		"note": map[string]interface{}{
+			"id":     noteID,
+			"fields": fields,
+		},
+	}
+}
+
+// UpdateNoteTags replaces the tags of an existing note
+func (c *Client) UpdateNoteTags(noteID int64, tags []string) error {
+	return c.UpdateNoteTagsContext(context.Background(), noteID, tags)
+}
+
+// UpdateNoteTagsContext replaces the tags of an existing note using the given context
+func (c *Client) UpdateNoteTagsContext(ctx context.Context, noteID int64, tags []string) error {
+	params := map[string]interface{}{
+		"note": noteID,
+		"tags": tags,
+	}
+
+	_, err := c.CallContext(ctx, "updateNoteTags", params)
+	if err != nil {
+		return fmt.Errorf("failed to update note tags: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteNotes deletes the given notes together with all of their cards
+func (c *Client) DeleteNotes(noteIDs []int64) error {
+	return c.DeleteNotesContext(context.Background(), noteIDs)
+}
+
+// DeleteNotesContext deletes the given notes together with all of their cards using the given context
+func (c *Client) DeleteNotesContext(ctx context.Context, noteIDs []int64) error {
+	if len(noteIDs) == 0 {
+		return nil
+	}
+
+	params := map[string]interface{}{
+		"notes": noteIDs,
+	}
+
+	_, err := c.CallContext(ctx, "deleteNotes", params)
+	if err != nil {
+		return fmt.Errorf("failed to delete notes: %w", err)
+	}
+
+	return nil
+}
+
+// queryEscaper escapes characters with a special meaning in Anki search terms
+var queryEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	`*`, `\*`,
+	`_`, `\_`,
+)
+
+// FieldQuery builds an Anki search term matching notes whose field equals value exactly
+func FieldQuery(field, value string) string {
+	return fmt.Sprintf(`"%s:%s"`, queryEscaper.Replace(field), queryEscaper.Replace(value))
+}
+
+// NoteTypeQuery builds an Anki search term matching notes of the given model
+func NoteTypeQuery(modelName string) string {
+	return fmt.Sprintf(`"note:%s"`, queryEscaper.Replace(modelName))
+}
@@ -0,0 +1,230 @@
+package anki
+
+import (
+	"net/http"
+	"testing"
+
+	"anki-japanese-cli/internal/config"
+)
+
+func TestClient_FindNotes(t *testing.T) {
+	tests := []struct {
+		name        string
+		mockBody    string
+		expectError bool
+		expectIDs   []int64
+	}{
+		{
+			name:      "Notes found",
+			mockBody:  `{"result": [1483959289817, 1483959291695], "error": null}`,
+			expectIDs: []int64{1483959289817, 1483959291695},
+		},
+		{
+			name:      "No notes found",
+			mockBody:  `{"result": [], "error": null}`,
+			expectIDs: []int64{},
+		},
+		{
+			name:        "Invalid query",
+			mockBody:    `{"result": null, "error": "invalid search"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := NewMockHTTPClient(http.StatusOK, tt.mockBody, nil)
+			cfg := &config.AnkiConfig{
+				ConnectURL: "http://localhost:8765",
+				DeckName:   "test",
+			}
+			client := NewClientWithHTTPClient(cfg, mockClient)
+
+			ids, err := client.FindNotes("deck:日文動詞")
+			if (err != nil) != tt.expectError {
+				t.Fatalf("FindNotes() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if len(ids) != len(tt.expectIDs) {
+				t.Fatalf("FindNotes() returned %d IDs, expected %d", len(ids), len(tt.expectIDs))
+			}
+			for i := range ids {
+				if ids[i] != tt.expectIDs[i] {
+					t.Errorf("FindNotes() ID[%d] = %d, expected %d", i, ids[i], tt.expectIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestClient_NotesInfo(t *testing.T) {
+	mockBody := `{"result": [
+		{
+			"noteId": 1502298033753,
+			"profile": "User_1",
+			"modelName": "Japanese Verb",
+			"tags": ["anki-japanese-cli", "verb"],
+			"fields": {
+				"核心單字": {"value": "飲む", "order": 0},
+				"核心意義": {"value": "喝", "order": 2}
+			},
+			"mod": 1718377864,
+			"cards": [1498938915662]
+		},
+		{}
+	], "error": null}`
+
+	mockClient := NewMockHTTPClient(http.StatusOK, mockBody, nil)
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	notes, err := client.NotesInfo([]int64{1502298033753, 1})
+	if err != nil {
+		t.Fatalf("NotesInfo() error = %v", err)
+	}
+	if len(notes) != 1 {
+		t.Fatalf("NotesInfo() returned %d notes, expected 1 (missing notes skipped)", len(notes))
+	}
+
+	note := notes[0]
+	if note.NoteID != 1502298033753 || note.ModelName != "Japanese Verb" {
+		t.Errorf("NotesInfo() note = %+v", note)
+	}
+	if len(note.Tags) != 2 || len(note.Cards) != 1 {
+		t.Errorf("NotesInfo() tags = %v, cards = %v", note.Tags, note.Cards)
+	}
+
+	values := note.FieldValues()
+	if values["核心單字"] != "飲む" || values["核心意義"] != "喝" {
+		t.Errorf("FieldValues() = %v", values)
+	}
+
+	// Empty input must not hit the API
+	notes, err = client.NotesInfo(nil)
+	if err != nil || notes != nil {
+		t.Errorf("NotesInfo(nil) = %v, %v", notes, err)
+	}
+}
+
+func TestClient_UpdateNote(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	mockClient := NewMockHTTPClientWithMultipleResponses(map[string]struct {
+		StatusCode int
+		Body       string
+		Error      error
+	}{
+		"updateNoteFields": {StatusCode: http.StatusOK, Body: `{"result": null, "error": null}`},
+		"updateNoteTags":   {StatusCode: http.StatusOK, Body: `{"result": null, "error": null}`},
+	})
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	if err := client.UpdateNoteFields(1502298033753, map[string]string{"例句翻譯": "喝水"}); err != nil {
+		t.Errorf("UpdateNoteFields() error = %v", err)
+	}
+	if err := client.UpdateNoteTags(1502298033753, []string{"N5"}); err != nil {
+		t.Errorf("UpdateNoteTags() error = %v", err)
+	}
+
+	errorClient := NewClientWithHTTPClient(cfg, NewMockHTTPClient(http.StatusOK, `{"result": null, "error": "note was not found: 1"}`, nil))
+	if err := errorClient.UpdateNoteFields(1, map[string]string{"例句翻譯": "喝水"}); err == nil {
+		t.Error("UpdateNoteFields() expected error for missing note")
+	}
+}
+
+func TestFieldQuery(t *testing.T) {
+	tests := []struct {
+		field    string
+		value    string
+		expected string
+	}{
+		{"核心單字", "飲む", `"核心單字:飲む"`},
+		{"文法要點", "〜て_も*", `"文法要點:〜て\_も\*"`},
+		{"核心單字", `say "hi"`, `"核心單字:say \"hi\""`},
+	}
+
+	for _, tt := range tests {
+		if got := FieldQuery(tt.field, tt.value); got != tt.expected {
+			t.Errorf("FieldQuery(%q, %q) = %s, expected %s", tt.field, tt.value, got, tt.expected)
+		}
+	}
+
+	if got := NoteTypeQuery("Japanese Verb"); got != `"note:Japanese Verb"` {
+		t.Errorf("NoteTypeQuery() = %s", got)
+	}
+}
+
+func TestClient_DeleteAndSuspend(t *testing.T) {
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+
+	mockClient := NewMockHTTPClientWithMultipleResponses(map[string]struct {
+		StatusCode int
+		Body       string
+		Error      error
+	}{
+		"deleteNotes": {StatusCode: http.StatusOK, Body: `{"result": null, "error": null}`},
+		"suspend":     {StatusCode: http.StatusOK, Body: `{"result": true, "error": null}`},
+		"unsuspend":   {StatusCode: http.StatusOK, Body: `{"result": false, "error": null}`},
+	})
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	if err := client.DeleteNotes([]int64{1502298033753}); err != nil {
+		t.Errorf("DeleteNotes() error = %v", err)
+	}
+
+	changed, err := client.SuspendCards([]int64{1498938915662})
+	if err != nil || !changed {
+		t.Errorf("SuspendCards() = %v, %v; expected true, nil", changed, err)
+	}
+
+	changed, err = client.UnsuspendCards([]int64{1498938915662})
+	if err != nil || changed {
+		t.Errorf("UnsuspendCards() = %v, %v; expected false, nil", changed, err)
+	}
+
+	// Empty input must not hit the API
+	changed, err = client.SuspendCards(nil)
+	if err != nil || changed {
+		t.Errorf("SuspendCards(nil) = %v, %v", changed, err)
+	}
+}
+
+func TestClient_CanAddNotes(t *testing.T) {
+	mockBody := `{"result": [true, false], "error": null}`
+	mockClient := NewMockHTTPClient(http.StatusOK, mockBody, nil)
+	cfg := &config.AnkiConfig{
+		ConnectURL: "http://localhost:8765",
+		DeckName:   "test",
+	}
+	client := NewClientWithHTTPClient(cfg, mockClient)
+
+	notes := []NoteInfo{
+		{DeckName: "test", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": "食べる"}},
+		{DeckName: "test", ModelName: "Japanese Verb", Fields: map[string]string{"核心單字": "飲む"}},
+	}
+
+	canAdd, err := client.CanAddNotes(notes)
+	if err != nil {
+		t.Fatalf("CanAddNotes() error = %v", err)
+	}
+	if len(canAdd) != 2 || !canAdd[0] || canAdd[1] {
+		t.Errorf("CanAddNotes() = %v, expected [true false]", canAdd)
+	}
+
+	// Empty input does not call Anki
+	canAdd, err = client.CanAddNotes(nil)
+	if err != nil || canAdd != nil {
+		t.Errorf("CanAddNotes(nil) = %v, %v, expected nil, nil", canAdd, err)
+	}
+}
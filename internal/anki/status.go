@@ -1,7 +1,10 @@
 package anki
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"strings"
 	"time"
@@ -12,32 +15,40 @@ type ConnectionStatus struct {
 	Connected      bool
 	Version        string
 	Error          string
+	Err            error
 	ResponseTimeMs int64
 	URL            string
 }
 
 // CheckConnection checks if the connection to Anki is working
 func (c *Client) CheckConnection() ConnectionStatus {
+	return c.CheckConnectionContext(context.Background())
+}
+
+// CheckConnectionContext checks if the connection to Anki is working using the given context
+func (c *Client) CheckConnectionContext(ctx context.Context) ConnectionStatus {
 	status := ConnectionStatus{
 		Connected: false,
 		URL:       c.config.ConnectURL,
 	}
 
 	startTime := time.Now()
-	result, err := c.Call("version", nil)
+	result, err := c.CallContext(ctx, "version", nil)
 	endTime := time.Now()
 
 	status.ResponseTimeMs = endTime.Sub(startTime).Milliseconds()
 
 	if err != nil {
 		status.Error = err.Error()
+		status.Err = err
 		return status
 	}
 
 	// Convert the result to a string
 	version, ok := result.(float64)
 	if !ok {
-		status.Error = fmt.Sprintf("unexpected result type: %T", result)
+		status.Err = fmt.Errorf("unexpected result type: %T", result)
+		status.Error = status.Err.Error()
 		return status
 	}
 
@@ -49,7 +60,12 @@ func (c *Client) CheckConnection() ConnectionStatus {
 
 // DiagnoseConnection provides diagnostic information about the connection
 func (c *Client) DiagnoseConnection() string {
-	status := c.CheckConnection()
+	return c.DiagnoseConnectionContext(context.Background())
+}
+
+// DiagnoseConnectionContext provides diagnostic information about the connection using the given context
+func (c *Client) DiagnoseConnectionContext(ctx context.Context) string {
+	status := c.CheckConnectionContext(ctx)
 
 	var sb strings.Builder
 
@@ -65,7 +81,7 @@ func (c *Client) DiagnoseConnection() string {
 		sb.WriteString("\n錯誤診斷:\n")
 		sb.WriteString(fmt.Sprintf("錯誤訊息: %s\n", status.Error))
 		sb.WriteString("\n可能的解決方案:\n")
-		sb.WriteString(getErrorSuggestions(status.Error, status.URL))
+		sb.WriteString(getErrorSuggestions(status.Err, status.URL))
 	}
 
 	return sb.String()
@@ -80,21 +96,30 @@ func getStatusText(connected bool) string {
 }
 
 // getErrorSuggestions returns suggestions for fixing the error
-func getErrorSuggestions(errMsg, connectURL string) string {
+func getErrorSuggestions(err error, connectURL string) string {
 	var sb strings.Builder
 
-	// Check for common error patterns
-	if strings.Contains(errMsg, "connection refused") {
+	var refusedErr *ConnectionRefusedError
+	var dnsErr *net.DNSError
+	var timeoutErr *TimeoutError
+	var statusErr *HTTPStatusError
+	var apiErr *APIError
+
+	// Check for common error kinds
+	if errors.As(err, &refusedErr) {
 		sb.WriteString("1. 確認 Anki 是否已啟動\n")
 		sb.WriteString("2. 確認 AnkiConnect 插件是否已安裝\n")
 		sb.WriteString("3. 重新啟動 Anki\n")
-	} else if strings.Contains(errMsg, "no such host") {
+	} else if errors.As(err, &dnsErr) {
 		sb.WriteString("1. 檢查連線 URL 是否正確\n")
 		sb.WriteString("2. 檢查網路連線\n")
-	} else if strings.Contains(errMsg, "timeout") {
+	} else if errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded) {
 		sb.WriteString("1. Anki 可能正在處理其他請求，請稍後再試\n")
 		sb.WriteString("2. 檢查 Anki 是否響應\n")
-	} else if strings.Contains(errMsg, "API error") {
+	} else if errors.As(err, &statusErr) {
+		sb.WriteString("1. 確認連線 URL 指向 AnkiConnect 而非其他服務\n")
+		sb.WriteString("2. 檢查 AnkiConnect 的 webBindAddress 與 webBindPort 設定\n")
+	} else if errors.As(err, &apiErr) {
 		sb.WriteString("1. 檢查 AnkiConnect 插件版本是否最新\n")
 		sb.WriteString("2. 檢查請求參數是否正確\n")
 	} else {
@@ -104,8 +129,7 @@ func getErrorSuggestions(errMsg, connectURL string) string {
 	}
 
 	// Add URL validation suggestion if the URL seems invalid
-	_, err := url.Parse(connectURL)
-	if err != nil {
+	if _, parseErr := url.Parse(connectURL); parseErr != nil {
 		sb.WriteString("\n連線 URL 格式不正確，請檢查設定檔中的 anki.connect_url 值\n")
 		sb.WriteString("預設值應為: http://localhost:8765\n")
 	}
@@ -126,23 +150,36 @@ func FormatError(err error) string {
 		return ""
 	}
 
-	errMsg := err.Error()
+	var refusedErr *ConnectionRefusedError
+	var dnsErr *net.DNSError
+	var timeoutErr *TimeoutError
+	var statusErr *HTTPStatusError
+	var duplicateErr *DuplicateNoteError
+	var modelErr *ModelNotFoundError
+	var deckErr *DeckNotFoundError
+	var apiErr *APIError
 
 	// Make the error message more user-friendly
-	if strings.Contains(errMsg, "connection refused") {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "操作已取消。"
+	case errors.As(err, &refusedErr):
 		return "無法連線到 Anki。請確認 Anki 已啟動且 AnkiConnect 插件已安裝。"
-	} else if strings.Contains(errMsg, "no such host") {
+	case errors.As(err, &dnsErr):
 		return "找不到 Anki 伺服器。請檢查連線 URL 是否正確。"
-	} else if strings.Contains(errMsg, "timeout") {
+	case errors.As(err, &timeoutErr), errors.Is(err, context.DeadlineExceeded):
 		return "連線到 Anki 逾時。請確認 Anki 是否正常運作。"
-	} else if strings.Contains(errMsg, "API error") {
-		// Extract the actual API error message
-		parts := strings.Split(errMsg, "API error: ")
-		if len(parts) > 1 {
-			return fmt.Sprintf("Anki API 錯誤: %s", parts[1])
-		}
-		return "Anki API 發生錯誤。"
+	case errors.As(err, &statusErr):
+		return fmt.Sprintf("Anki Connect 回應了非預期的 HTTP 狀態碼 %d。請檢查連線 URL 是否正確。", statusErr.StatusCode)
+	case errors.As(err, &duplicateErr):
+		return "卡片重複: Anki 中已存在相同的筆記。"
+	case errors.As(err, &modelErr):
+		return fmt.Sprintf("找不到模型 '%s'。請先執行 init 指令建立模型。", modelErr.ModelName)
+	case errors.As(err, &deckErr):
+		return fmt.Sprintf("找不到牌組 '%s'。", deckErr.DeckName)
+	case errors.As(err, &apiErr):
+		return fmt.Sprintf("Anki API 錯誤: %s", apiErr.Message)
 	}
 
-	return fmt.Sprintf("錯誤: %s", errMsg)
+	return fmt.Sprintf("錯誤: %s", err.Error())
 }
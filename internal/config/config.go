@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/viper"
 )
@@ -67,6 +68,20 @@ func LoadConfig() (*Config, error) {
 	return &config, nil
 }
 
+// CardTypesDir 回傳自訂卡片類型 (YAML 檔案) 所在的目錄。
+// 預設為 $HOME/.anki-japanese-cli/card-types，可由設定檔的 card_types_dir 覆寫
+func CardTypesDir() (string, error) {
+	if dir := viper.GetString("card_types_dir"); dir != "" {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("無法取得使用者家目錄: %w", err)
+	}
+	return filepath.Join(home, ".anki-japanese-cli", "card-types"), nil
+}
+
 // SaveConfig 儲存設定到檔案
 func SaveConfig(config *Config) error {
 	home, err := os.UserHomeDir()
@@ -0,0 +1,89 @@
+package conjugation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AdjectiveClass 形容詞的種類
+type AdjectiveClass int
+
+const (
+	IAdjective  AdjectiveClass = iota + 1 // い形容詞
+	NaAdjective                           // な形容詞 (形容動詞)
+)
+
+// String 回傳形容詞種類的名稱
+func (c AdjectiveClass) String() string {
+	switch c {
+	case IAdjective:
+		return "い形容詞"
+	case NaAdjective:
+		return "な形容詞"
+	}
+	return "未知"
+}
+
+// 形容詞變化的名稱 (主要變化中使用的標籤)；て形與條件形與動詞共用
+const (
+	FormNegative     = "否定形"
+	FormPast         = "過去形"
+	FormPastNegative = "過去否定形"
+	FormAdverbial    = "副詞形"
+)
+
+// ClassifyAdjective 由詞性分類判斷形容詞的種類。
+// 支援 い形容詞/イ形容詞/形容詞 與 な形容詞/ナ形容詞/形容動詞 的寫法
+func ClassifyAdjective(wordType string) (AdjectiveClass, error) {
+	t := strings.ToLower(strings.TrimSpace(wordType))
+	switch {
+	case t == "":
+		return 0, fmt.Errorf("未指定詞性分類")
+	case containsAny(t, "な形容", "ナ形容", "形容動詞", "na-adj", "na adj"):
+		return NaAdjective, nil
+	case containsAny(t, "い形容", "イ形容", "i-adj", "i adj") || t == "形容詞":
+		return IAdjective, nil
+	}
+	return 0, fmt.Errorf("無法辨識形容詞種類: %s (可用: い形容詞, な形容詞)", wordType)
+}
+
+// goodAdjectives 以「いい」結尾、變化時改用「よ」的形容詞 (いい → よくない)
+var goodAdjectives = []string{"かっこいい", "格好いい", "気持ちいい", "心地いい", "ちょうどいい", "がいい"}
+
+// InflectAdjective 由辭書形產生形容詞的主要變化
+// (否定形、過去形、過去否定形、て形、副詞形、條件形)。
+// な形容詞的辭書形可包含結尾的「な」；口語的 じゃない 與 ならば 視為正確的其他寫法
+func InflectAdjective(word string, class AdjectiveClass) (Forms, error) {
+	switch class {
+	case IAdjective:
+		stem, ok := strings.CutSuffix(word, "い")
+		if !ok || stem == "" {
+			return nil, fmt.Errorf("い形容詞 '%s' 必須以「い」結尾", word)
+		}
+		if word == "いい" || hasAnySuffix(word, goodAdjectives...) {
+			stem = strings.TrimSuffix(word, "いい") + "よ"
+		}
+		return Forms{
+			{Name: FormNegative, Value: stem + "くない", Variants: []string{stem + "くありません"}},
+			{Name: FormPast, Value: stem + "かった"},
+			{Name: FormPastNegative, Value: stem + "くなかった", Variants: []string{stem + "くありませんでした"}},
+			{Name: FormTe, Value: stem + "くて"},
+			{Name: FormAdverbial, Value: stem + "く"},
+			{Name: FormConditional, Value: stem + "ければ"},
+		}, nil
+	case NaAdjective:
+		stem := strings.TrimSuffix(word, "な")
+		if stem == "" {
+			return nil, fmt.Errorf("な形容詞 '%s' 無效", word)
+		}
+		return Forms{
+			{Name: FormNegative, Value: stem + "ではない", Variants: []string{stem + "じゃない", stem + "ではありません", stem + "じゃありません"}},
+			{Name: FormPast, Value: stem + "だった", Variants: []string{stem + "でした"}},
+			{Name: FormPastNegative, Value: stem + "ではなかった", Variants: []string{stem + "じゃなかった", stem + "ではありませんでした", stem + "じゃありませんでした"}},
+			{Name: FormTe, Value: stem + "で"},
+			{Name: FormAdverbial, Value: stem + "に"},
+			{Name: FormConditional, Value: stem + "なら", Variants: []string{stem + "ならば", stem + "であれば"}},
+		}, nil
+	}
+	return nil, fmt.Errorf("不支援的形容詞種類: %d", class)
+}
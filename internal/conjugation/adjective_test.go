@@ -0,0 +1,89 @@
+package conjugation
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestClassifyAdjective tests recognising the adjective class from 詞性分類
+func TestClassifyAdjective(t *testing.T) {
+	tests := []struct {
+		wordType string
+		expected AdjectiveClass
+		wantErr  bool
+	}{
+		{"い形容詞", IAdjective, false},
+		{"イ形容詞", IAdjective, false},
+		{"形容詞", IAdjective, false},
+		{"な形容詞", NaAdjective, false},
+		{"形容動詞", NaAdjective, false},
+		{"", 0, true},
+		{"名詞", 0, true},
+		{"五段動詞", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wordType, func(t *testing.T) {
+			class, err := ClassifyAdjective(tt.wordType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClassifyAdjective() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if class != tt.expected {
+				t.Errorf("ClassifyAdjective() = %v, expected %v", class, tt.expected)
+			}
+		})
+	}
+}
+
+// TestInflectAdjective tests every form for both adjective classes and the いい exception
+func TestInflectAdjective(t *testing.T) {
+	tests := []struct {
+		word     string
+		class    AdjectiveClass
+		expected string // 否定、過去、過去否定、て、副詞、條件
+	}{
+		{"美しい", IAdjective, "美しくない 美しかった 美しくなかった 美しくて 美しく 美しければ"},
+		{"高い", IAdjective, "高くない 高かった 高くなかった 高くて 高く 高ければ"},
+		{"いい", IAdjective, "よくない よかった よくなかった よくて よく よければ"},
+		{"かっこいい", IAdjective, "かっこよくない かっこよかった かっこよくなかった かっこよくて かっこよく かっこよければ"},
+		{"頭がいい", IAdjective, "頭がよくない 頭がよかった 頭がよくなかった 頭がよくて 頭がよく 頭がよければ"},
+		{"かわいい", IAdjective, "かわいくない かわいかった かわいくなかった かわいくて かわいく かわいければ"},
+		{"静か", NaAdjective, "静かではない 静かだった 静かではなかった 静かで 静かに 静かなら"},
+		{"きれいな", NaAdjective, "きれいではない きれいだった きれいではなかった きれいで きれいに きれいなら"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			forms, err := InflectAdjective(tt.word, tt.class)
+			if err != nil {
+				t.Fatalf("InflectAdjective() error = %v", err)
+			}
+			var values []string
+			for _, form := range forms {
+				values = append(values, form.Value)
+			}
+			if got := strings.Join(values, " "); got != tt.expected {
+				t.Errorf("InflectAdjective(%s) =\n%s\nexpected\n%s", tt.word, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := InflectAdjective("静か", IAdjective); err == nil {
+		t.Error("InflectAdjective() expected an error for an い形容詞 not ending in い")
+	}
+}
+
+// TestVerifyAdjective tests that colloquial variants and the card's labels are accepted
+func TestVerifyAdjective(t *testing.T) {
+	forms, _ := InflectAdjective("静か", NaAdjective)
+
+	text := "否定形: 静かじゃない<br>過去形: 静かだった<br>過去否定形: 静かじゃなかった<br>て形: 静かで"
+	if mismatches := Verify(text, forms); len(mismatches) != 0 {
+		t.Errorf("Verify() = %v, expected no mismatches", mismatches)
+	}
+
+	mismatches := Verify("副詞形: 静かく", forms)
+	if len(mismatches) != 1 || mismatches[0].Expected != "静かに" {
+		t.Errorf("Verify() = %v, expected the 副詞形 to be reported", mismatches)
+	}
+}
@@ -0,0 +1,130 @@
+// Package conjugation 產生日文動詞與形容詞的活用形，並比對卡片中手寫的變化
+package conjugation
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Form 單一活用形 (例如 て形: 飲んで)
+type Form struct {
+	Name     string
+	Value    string
+	Variants []string // 也視為正確的其他寫法 (例如 静かじゃない)
+}
+
+// Forms 依固定順序排列的活用形
+type Forms []Form
+
+// Get 取得指定名稱的活用形
+func (fs Forms) Get(name string) (string, bool) {
+	for _, form := range fs {
+		if form.Name == name {
+			return form.Value, true
+		}
+	}
+	return "", false
+}
+
+// String 以卡片欄位使用的格式輸出 (例如 "ます形: 飲みます<br>て形: 飲んで")
+func (fs Forms) String() string {
+	parts := make([]string, len(fs))
+	for i, form := range fs {
+		parts[i] = form.Name + ": " + form.Value
+	}
+	return strings.Join(parts, "<br>")
+}
+
+// formAliases 常用變化中常見的標籤寫法 → 標準名稱 (標籤與產生的活用形名稱相同時優先使用)
+var formAliases = map[string]string{
+	"丁寧形": FormMasu, "敬語形": FormMasu,
+	"過去形": FormTa, "否定形": FormNai,
+	"被動形": FormPassive,
+	"意志形": FormVolitional,
+	"条件形": FormConditional, "ば形": FormConditional, "假定形": FormConditional, "仮定形": FormConditional,
+	"否定過去形": FormPastNegative, "過去式否定": FormPastNegative,
+	"連用形": FormAdverbial, "く形": FormAdverbial,
+}
+
+// lineSeparator 常用變化中分隔各項的 <br> 或換行
+var lineSeparator = regexp.MustCompile(`(?i)<br\s*/?>|\n`)
+
+// ParseForms 解析常用變化的文字 ("ます形: 飲みます<br>て形: 飲んで")，標籤保持原樣；
+// 沒有標籤的項目會被忽略
+func ParseForms(text string) Forms {
+	var forms Forms
+	for _, line := range lineSeparator.Split(text, -1) {
+		label, value, ok := strings.Cut(strings.ReplaceAll(line, "：", ":"), ":")
+		if !ok || strings.TrimSpace(label) == "" {
+			continue
+		}
+		forms = append(forms, Form{Name: strings.TrimSpace(label), Value: strings.TrimSpace(value)})
+	}
+	return forms
+}
+
+// resolveName 將標籤對應到產生的活用形名稱：名稱相同者優先，其次為常見的別名
+func resolveName(label string, forms Forms) (string, bool) {
+	if _, ok := forms.Get(label); ok {
+		return label, true
+	}
+	if name, ok := formAliases[label]; ok {
+		if _, ok := forms.Get(name); ok {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Mismatch 常用變化中與產生的活用形不一致的項目
+type Mismatch struct {
+	Name     string
+	Got      string
+	Expected string
+}
+
+// Verify 比對常用變化與產生的活用形，回傳不一致的項目。
+// expected 可提供多組寫法 (例如漢字與假名)，項目符合任一組即可；
+// 一個項目可用 /、、 或 , 列出多個寫法，其中之一正確即視為一致
+func Verify(text string, expected ...Forms) []Mismatch {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	var mismatches []Mismatch
+	for _, form := range ParseForms(text) {
+		name, ok := resolveName(form.Name, expected[0])
+		if !ok {
+			continue
+		}
+		form.Name = name
+		if matchesAny(form, expected) {
+			continue
+		}
+		want, _ := expected[0].Get(name)
+		mismatches = append(mismatches, Mismatch{Name: name, Got: form.Value, Expected: want})
+	}
+	return mismatches
+}
+
+// matchesAny 檢查活用形的任一寫法是否與任一組產生的活用形相同
+func matchesAny(form Form, expected []Forms) bool {
+	alternatives := strings.FieldsFunc(form.Value, func(r rune) bool {
+		return strings.ContainsRune("/／、,，", r)
+	})
+	for _, forms := range expected {
+		for _, candidate := range forms {
+			if candidate.Name != form.Name {
+				continue
+			}
+			for _, alternative := range alternatives {
+				alternative = strings.TrimSpace(alternative)
+				if alternative == candidate.Value || slices.Contains(candidate.Variants, alternative) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package conjugation
+
+import "testing"
+
+// TestParseForms tests parsing hand-written conjugations into labelled items
+func TestParseForms(t *testing.T) {
+	forms := ParseForms("ます形: 飲みます<br>て形：飲んで<br/>否定形: 飲まない\n飲んだ")
+
+	expected := []string{"ます形=飲みます", "て形=飲んで", "否定形=飲まない"}
+	if len(forms) != len(expected) {
+		t.Fatalf("ParseForms() = %v, expected %v", forms, expected)
+	}
+	for i := range expected {
+		if got := forms[i].Name + "=" + forms[i].Value; got != expected[i] {
+			t.Errorf("ParseForms()[%d] = %s, expected %s", i, got, expected[i])
+		}
+	}
+}
+
+// TestFormsString tests the field format produced for 常用變化
+func TestFormsString(t *testing.T) {
+	forms := Forms{{Name: FormMasu, Value: "飲みます"}, {Name: FormTe, Value: "飲んで"}}
+	if got := forms.String(); got != "ます形: 飲みます<br>て形: 飲んで" {
+		t.Errorf("String() = %q", got)
+	}
+	if forms := ParseForms(forms.String()); len(forms) != 2 {
+		t.Errorf("ParseForms(String()) = %v, expected a round trip", forms)
+	}
+}
+
+// TestVerify tests cross-checking hand-written conjugations
+func TestVerify(t *testing.T) {
+	kanji, _ := ConjugateVerb("飲む", Godan)
+	kana, _ := ConjugateVerb("のむ", Godan)
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []Mismatch
+	}{
+		{"consistent", "ます形: 飲みます<br>て形: 飲んで<br>ない形: 飲まない<br>た形: 飲んだ", nil},
+		{"kana and alternatives", "ます形: のみます<br>ない形: 飲まない / 飲みません", nil},
+		{"unknown labels ignored", "敬語: 召し上がる", nil},
+		{"label aliases", "否定形: 飲まない<br>過去形: 飲んだ", nil},
+		{"wrong te form", "て形: 飲みて<br>た形: 飲んだ", []Mismatch{{FormTe, "飲みて", "飲んで"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatches := Verify(tt.text, kanji, kana)
+			if len(mismatches) != len(tt.expected) {
+				t.Fatalf("Verify() = %v, expected %v", mismatches, tt.expected)
+			}
+			for i := range tt.expected {
+				if mismatches[i] != tt.expected[i] {
+					t.Errorf("Verify()[%d] = %v, expected %v", i, mismatches[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package conjugation
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// VerbClass 動詞的活用種類
+type VerbClass int
+
+const (
+	Godan   VerbClass = iota + 1 // 五段動詞
+	Ichidan                      // 一段動詞
+	Suru                         // サ行變格動詞 (する、勉強する)
+	Kuru                         // カ行變格動詞 (来る)
+)
+
+// String 回傳活用種類的名稱
+func (c VerbClass) String() string {
+	switch c {
+	case Godan:
+		return "五段動詞"
+	case Ichidan:
+		return "一段動詞"
+	case Suru:
+		return "サ變動詞"
+	case Kuru:
+		return "カ變動詞"
+	}
+	return "未知"
+}
+
+// 動詞活用形的名稱 (常用變化中使用的標籤)
+const (
+	FormMasu        = "ます形"
+	FormTe          = "て形"
+	FormTa          = "た形"
+	FormNai         = "ない形"
+	FormPotential   = "可能形"
+	FormPassive     = "受身形"
+	FormCausative   = "使役形"
+	FormVolitional  = "意向形"
+	FormImperative  = "命令形"
+	FormConditional = "條件形"
+)
+
+// ClassifyVerb 由詞性分類判斷動詞的活用種類。
+// 支援 五段/一段/サ變/カ變 與 第一類/第二類/第三類 的寫法；
+// 詞性分類為空或為第三類時，依單字結尾判斷する動詞或来る
+func ClassifyVerb(word, wordType string) (VerbClass, error) {
+	t := strings.ToLower(strings.TrimSpace(wordType))
+	switch {
+	case containsAny(t, "五段", "godan", "一類", "1類", "Ⅰ類"):
+		return Godan, nil
+	case containsAny(t, "一段", "ichidan", "二類", "2類", "Ⅱ類"):
+		return Ichidan, nil
+	case containsAny(t, "カ変", "カ變", "kuru", "来る", "來る"):
+		return Kuru, nil
+	case containsAny(t, "サ変", "サ變", "する", "suru"):
+		return Suru, nil
+	case t == "" || containsAny(t, "三類", "3類", "Ⅲ類", "不規則"):
+		if hasAnySuffix(word, "来る", "來る", "くる") {
+			return Kuru, nil
+		}
+		if strings.HasSuffix(word, "する") {
+			return Suru, nil
+		}
+	}
+	if t == "" {
+		return 0, fmt.Errorf("未指定詞性分類，無法判斷 '%s' 的動詞種類", word)
+	}
+	return 0, fmt.Errorf("無法辨識動詞種類: %s", wordType)
+}
+
+// ConjugateVerb 由辭書形產生動詞的常用活用形
+// (ます形、て形、た形、ない形、可能形、受身形、使役形、意向形、命令形、條件形)
+func ConjugateVerb(word string, class VerbClass) (Forms, error) {
+	switch class {
+	case Godan:
+		return conjugateGodan(word)
+	case Ichidan:
+		stem, ok := strings.CutSuffix(word, "る")
+		if !ok || stem == "" {
+			return nil, fmt.Errorf("一段動詞 '%s' 必須以「る」結尾", word)
+		}
+		return verbForms(stem+"ます", stem+"て", stem+"た", stem+"ない", stem+"られる",
+			stem+"られる", stem+"させる", stem+"よう", stem+"ろ", stem+"れば"), nil
+	case Suru:
+		prefix, ok := strings.CutSuffix(word, "する")
+		if !ok {
+			return nil, fmt.Errorf("サ變動詞 '%s' 必須以「する」結尾", word)
+		}
+		return verbForms(prefix+"します", prefix+"して", prefix+"した", prefix+"しない", prefix+"できる",
+			prefix+"される", prefix+"させる", prefix+"しよう", prefix+"しろ", prefix+"すれば"), nil
+	case Kuru:
+		return conjugateKuru(word)
+	}
+	return nil, fmt.Errorf("不支援的動詞種類: %d", class)
+}
+
+// godanRows 五段動詞語尾對應的 い段、あ段、え段、お段 假名
+var godanRows = map[rune][4]string{
+	'う': {"い", "わ", "え", "お"},
+	'く': {"き", "か", "け", "こ"},
+	'ぐ': {"ぎ", "が", "げ", "ご"},
+	'す': {"し", "さ", "せ", "そ"},
+	'つ': {"ち", "た", "て", "と"},
+	'ぬ': {"に", "な", "ね", "の"},
+	'ぶ': {"び", "ば", "べ", "ぼ"},
+	'む': {"み", "ま", "め", "も"},
+	'る': {"り", "ら", "れ", "ろ"},
+}
+
+// honorificVerbs ます形與命令形為「い」的敬語動詞 (いらっしゃいます、ください)
+var honorificVerbs = []string{"いらっしゃる", "おっしゃる", "仰る", "くださる", "下さる", "なさる", "ござる"}
+
+// conjugateGodan 五段動詞的活用，包含 行く、ある、問う 與敬語動詞等例外
+func conjugateGodan(word string) (Forms, error) {
+	last, size := utf8.DecodeLastRuneInString(word)
+	row, ok := godanRows[last]
+	if !ok || len(word) == size {
+		return nil, fmt.Errorf("五段動詞 '%s' 必須以う段假名結尾", word)
+	}
+	stem := word[:len(word)-size]
+	i, a, e, o := row[0], row[1], row[2], row[3]
+
+	// て形與た形的音便
+	var te, ta string
+	switch last {
+	case 'う', 'つ', 'る':
+		te, ta = "って", "った"
+	case 'む', 'ぶ', 'ぬ':
+		te, ta = "んで", "んだ"
+	case 'く':
+		te, ta = "いて", "いた"
+	case 'ぐ':
+		te, ta = "いで", "いだ"
+	case 'す':
+		te, ta = "して", "した"
+	}
+	switch {
+	case word == "いく" || hasAnySuffix(word, "行く", "逝く"):
+		te, ta = "って", "った"
+	case hasAnySuffix(word, "問う", "請う", "乞う"):
+		te, ta = "うて", "うた"
+	}
+
+	masu, imperative := stem+i+"ます", stem+e
+	if hasAnySuffix(word, honorificVerbs...) {
+		masu, imperative = stem+"います", stem+"い"
+	}
+
+	nai := stem + a + "ない"
+	if word == "ある" || word == "有る" || word == "在る" {
+		nai = "ない"
+	}
+
+	return verbForms(masu, stem+te, stem+ta, nai, stem+e+"る",
+		stem+a+"れる", stem+a+"せる", stem+o+"う", imperative, stem+e+"ば"), nil
+}
+
+// conjugateKuru 来る (漢字) 或 くる (假名) 的活用，可包含前面的詞 (例如 持って来る)
+func conjugateKuru(word string) (Forms, error) {
+	for _, kanji := range []string{"来", "來"} {
+		if prefix, ok := strings.CutSuffix(word, kanji+"る"); ok {
+			k := prefix + kanji
+			return verbForms(k+"ます", k+"て", k+"た", k+"ない", k+"られる",
+				k+"られる", k+"させる", k+"よう", k+"い", k+"れば"), nil
+		}
+	}
+	if prefix, ok := strings.CutSuffix(word, "くる"); ok {
+		return verbForms(prefix+"きます", prefix+"きて", prefix+"きた", prefix+"こない", prefix+"こられる",
+			prefix+"こられる", prefix+"こさせる", prefix+"こよう", prefix+"こい", prefix+"くれば"), nil
+	}
+	return nil, fmt.Errorf("カ變動詞 '%s' 必須以「来る」或「くる」結尾", word)
+}
+
+// verbForms 依固定順序組成動詞活用形列表
+func verbForms(masu, te, ta, nai, potential, passive, causative, volitional, imperative, conditional string) Forms {
+	return Forms{
+		{Name: FormMasu, Value: masu},
+		{Name: FormTe, Value: te},
+		{Name: FormTa, Value: ta},
+		{Name: FormNai, Value: nai},
+		{Name: FormPotential, Value: potential},
+		{Name: FormPassive, Value: passive},
+		{Name: FormCausative, Value: causative},
+		{Name: FormVolitional, Value: volitional},
+		{Name: FormImperative, Value: imperative},
+		{Name: FormConditional, Value: conditional},
+	}
+}
+
+// containsAny 檢查字串是否包含任一子字串
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnySuffix 檢查字串是否以任一後綴結尾
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
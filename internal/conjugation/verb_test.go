@@ -0,0 +1,105 @@
+package conjugation
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestClassifyVerb tests recognising the verb class from 詞性分類
+func TestClassifyVerb(t *testing.T) {
+	tests := []struct {
+		word     string
+		wordType string
+		expected VerbClass
+		wantErr  bool
+	}{
+		{"飲む", "五段動詞", Godan, false},
+		{"食べる", "下一段動詞", Ichidan, false},
+		{"見る", "上一段動詞", Ichidan, false},
+		{"走る", "第一類動詞", Godan, false},
+		{"起きる", "第二類動詞", Ichidan, false},
+		{"勉強する", "第三類動詞", Suru, false},
+		{"来る", "第三類動詞", Kuru, false},
+		{"来る", "カ変動詞", Kuru, false},
+		{"する", "サ変動詞", Suru, false},
+		{"勉強する", "", Suru, false},
+		{"飲む", "", 0, true},
+		{"飲む", "名詞", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word+"/"+tt.wordType, func(t *testing.T) {
+			class, err := ClassifyVerb(tt.word, tt.wordType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ClassifyVerb() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if class != tt.expected {
+				t.Errorf("ClassifyVerb() = %v, expected %v", class, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConjugateVerb tests every form for each verb class and the common exceptions
+func TestConjugateVerb(t *testing.T) {
+	tests := []struct {
+		word     string
+		class    VerbClass
+		expected string // ます、て、た、ない、可能、受身、使役、意向、命令、條件
+	}{
+		{"飲む", Godan, "飲みます 飲んで 飲んだ 飲まない 飲める 飲まれる 飲ませる 飲もう 飲め 飲めば"},
+		{"書く", Godan, "書きます 書いて 書いた 書かない 書ける 書かれる 書かせる 書こう 書け 書けば"},
+		{"泳ぐ", Godan, "泳ぎます 泳いで 泳いだ 泳がない 泳げる 泳がれる 泳がせる 泳ごう 泳げ 泳げば"},
+		{"話す", Godan, "話します 話して 話した 話さない 話せる 話される 話させる 話そう 話せ 話せば"},
+		{"待つ", Godan, "待ちます 待って 待った 待たない 待てる 待たれる 待たせる 待とう 待て 待てば"},
+		{"死ぬ", Godan, "死にます 死んで 死んだ 死なない 死ねる 死なれる 死なせる 死のう 死ね 死ねば"},
+		{"遊ぶ", Godan, "遊びます 遊んで 遊んだ 遊ばない 遊べる 遊ばれる 遊ばせる 遊ぼう 遊べ 遊べば"},
+		{"走る", Godan, "走ります 走って 走った 走らない 走れる 走られる 走らせる 走ろう 走れ 走れば"},
+		{"買う", Godan, "買います 買って 買った 買わない 買える 買われる 買わせる 買おう 買え 買えば"},
+		{"行く", Godan, "行きます 行って 行った 行かない 行ける 行かれる 行かせる 行こう 行け 行けば"},
+		{"ある", Godan, "あります あって あった ない あれる あられる あらせる あろう あれ あれば"},
+		{"問う", Godan, "問います 問うて 問うた 問わない 問える 問われる 問わせる 問おう 問え 問えば"},
+		{"いらっしゃる", Godan, "いらっしゃいます いらっしゃって いらっしゃった いらっしゃらない いらっしゃれる いらっしゃられる いらっしゃらせる いらっしゃろう いらっしゃい いらっしゃれば"},
+		{"食べる", Ichidan, "食べます 食べて 食べた 食べない 食べられる 食べられる 食べさせる 食べよう 食べろ 食べれば"},
+		{"勉強する", Suru, "勉強します 勉強して 勉強した 勉強しない 勉強できる 勉強される 勉強させる 勉強しよう 勉強しろ 勉強すれば"},
+		{"来る", Kuru, "来ます 来て 来た 来ない 来られる 来られる 来させる 来よう 来い 来れば"},
+		{"くる", Kuru, "きます きて きた こない こられる こられる こさせる こよう こい くれば"},
+		{"持ってくる", Kuru, "持ってきます 持ってきて 持ってきた 持ってこない 持ってこられる 持ってこられる 持ってこさせる 持ってこよう 持ってこい 持ってくれば"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			forms, err := ConjugateVerb(tt.word, tt.class)
+			if err != nil {
+				t.Fatalf("ConjugateVerb() error = %v", err)
+			}
+			var values []string
+			for _, form := range forms {
+				values = append(values, form.Value)
+			}
+			if got := strings.Join(values, " "); got != tt.expected {
+				t.Errorf("ConjugateVerb(%s) =\n%s\nexpected\n%s", tt.word, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConjugateVerbInvalid tests dictionary forms that do not match the verb class
+func TestConjugateVerbInvalid(t *testing.T) {
+	tests := []struct {
+		word  string
+		class VerbClass
+	}{
+		{"飲み", Godan},
+		{"む", Godan},
+		{"食べた", Ichidan},
+		{"勉強", Suru},
+		{"行く", Kuru},
+	}
+
+	for _, tt := range tests {
+		if _, err := ConjugateVerb(tt.word, tt.class); err == nil {
+			t.Errorf("ConjugateVerb(%s, %v) expected an error", tt.word, tt.class)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package furigana
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"anki-japanese-cli/internal/japanese"
+)
+
+//go:embed dictionary.tsv
+var defaultDictionary string
+
+// Dictionary 單字與讀音的對照表，以最長比對為文字中的漢字標註讀音。
+//
+// 字典檔案每行為一個單字與其讀音，以 Tab 或空白分隔 (與 JMdict 匯出的讀音表相同)；
+// # 開頭的行為註解。只有包含漢字的單字會被使用。
+type Dictionary struct {
+	entries map[string]string // 單字 → 讀音 (平假名)
+	stems   map[string]string // 去除送假名的語幹 → 讀音，用於比對活用後的單字 (飲む → 飲み)
+	maxLen  int               // 最長單字的字數
+}
+
+// NewDictionary 建立空白的字典
+func NewDictionary() *Dictionary {
+	return &Dictionary{
+		entries: make(map[string]string),
+		stems:   make(map[string]string),
+	}
+}
+
+var (
+	defaultOnce sync.Once
+	defaultDict *Dictionary
+)
+
+// Default 回傳內建的字典 (常用單字，隨程式一起發佈)
+func Default() *Dictionary {
+	defaultOnce.Do(func() {
+		defaultDict = NewDictionary()
+		if err := defaultDict.Load(strings.NewReader(defaultDictionary)); err != nil {
+			panic(err)
+		}
+	})
+	return defaultDict
+}
+
+// LoadFile 建立包含內建字典與指定字典檔案的字典 (檔案中的讀音優先)
+func LoadFile(path string) (*Dictionary, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("無法讀取振り仮名字典: %w", err)
+	}
+	defer file.Close()
+
+	dict := NewDictionary()
+	if err := dict.Load(strings.NewReader(defaultDictionary)); err != nil {
+		return nil, err
+	}
+	if err := dict.Load(file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return dict, nil
+}
+
+// Load 從字典資料載入單字與讀音
+func (d *Dictionary) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("第 %d 行格式錯誤 (應為「單字<Tab>讀音」)", lineNo)
+		}
+		d.Add(fields[0], fields[1])
+	}
+	return scanner.Err()
+}
+
+// Add 加入單字與讀音；不含漢字的單字會被忽略。
+// 以送假名結尾的單字 (飲む/のむ) 也會登錄語幹 (飲/の)，語幹有多種讀音時不使用
+func (d *Dictionary) Add(word, reading string) {
+	if !japanese.ContainsKanji(word) || reading == "" {
+		return
+	}
+	reading = japanese.ToHiragana(reading)
+	d.entries[word] = reading
+	if n := utf8.RuneCountInString(word); n > d.maxLen {
+		d.maxLen = n
+	}
+
+	runes := []rune(word)
+	end := len(runes)
+	for end > 0 && !japanese.IsKanji(runes[end-1]) {
+		end--
+	}
+	okurigana := japanese.ToHiragana(string(runes[end:]))
+	if okurigana == "" || end == 0 || !strings.HasSuffix(reading, okurigana) {
+		return
+	}
+	stem, stemReading := string(runes[:end]), strings.TrimSuffix(reading, okurigana)
+	if existing, ok := d.stems[stem]; ok && existing != stemReading {
+		stemReading = ""
+	}
+	d.stems[stem] = stemReading
+}
+
+// Lookup 取得單字的讀音
+func (d *Dictionary) Lookup(word string) (string, bool) {
+	reading, ok := d.entries[word]
+	return reading, ok
+}
+
+// Len 回傳字典中的單字數
+func (d *Dictionary) Len() int {
+	return len(d.entries)
+}
+
+// Annotate 為文字中字典收錄的漢字加上 Anki 的振り仮名標註 (寝る前に → 寝[ね]る 前[まえ]に)；
+// 已經有標註的文字不會重複處理，字典中找不到的漢字保持原樣
+func (d *Dictionary) Annotate(text string) string {
+	if text == "" || HasFurigana(text) || !japanese.ContainsKanji(text) {
+		return text
+	}
+
+	runes := []rune(text)
+	var segments []Segment
+	var plain strings.Builder
+	for i := 0; i < len(runes); {
+		word, reading, n := d.longestMatch(runes[i:], i > 0 && japanese.IsKanji(runes[i-1]))
+		if n == 0 {
+			plain.WriteRune(runes[i])
+			i++
+			continue
+		}
+		if plain.Len() > 0 {
+			segments = append(segments, Segment{Text: plain.String()})
+			plain.Reset()
+		}
+		segments = append(segments, Align(word, reading)...)
+		i += n
+	}
+	if plain.Len() > 0 {
+		segments = append(segments, Segment{Text: plain.String()})
+	}
+	return Format(segments)
+}
+
+// longestMatch 找出從文字開頭起最長的字典單字 (完整單字優先於相同長度的語幹)；
+// 語幹前面不能是漢字、後面必須接著平假名 (活用語尾)，避免比對到複合詞的一部分 (未知 的 知)
+func (d *Dictionary) longestMatch(runes []rune, afterKanji bool) (string, string, int) {
+	for n := min(d.maxLen, len(runes)); n > 0; n-- {
+		candidate := string(runes[:n])
+		if reading, ok := d.entries[candidate]; ok {
+			return candidate, reading, n
+		}
+		if reading := d.stems[candidate]; reading != "" && !afterKanji && n < len(runes) && japanese.IsHiragana(runes[n]) {
+			return candidate, reading, n
+		}
+	}
+	return "", "", 0
+}
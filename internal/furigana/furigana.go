@@ -0,0 +1,140 @@
+// Package furigana 以讀音字典為日文文字的漢字加上 Anki 的振り仮名標註 (漢字[かんじ])，
+// 並解析標註以產生預覽用的 <ruby> 標記
+package furigana
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"anki-japanese-cli/internal/japanese"
+)
+
+// Segment 標註後的一段文字；Reading 不為空時 Text 為漢字，Reading 為其讀音
+type Segment struct {
+	Text    string
+	Reading string
+}
+
+// annotationPattern 比對 Anki 的振り仮名語法 (與 Anki 的 furigana 過濾器相同)：
+// 讀音前到空白 (或 >) 為止的文字為漢字部分，前面的空白會被移除
+var annotationPattern = regexp.MustCompile(` ?([^ >\[\]]+?)\[(.+?)\]`)
+
+// HasFurigana 檢查文字是否已包含振り仮名標註
+func HasFurigana(text string) bool {
+	return annotationPattern.MatchString(text)
+}
+
+// Parse 解析含有振り仮名標註的文字
+func Parse(text string) []Segment {
+	var segments []Segment
+	last := 0
+	for _, loc := range annotationPattern.FindAllStringSubmatchIndex(text, -1) {
+		if loc[0] > last {
+			segments = append(segments, Segment{Text: text[last:loc[0]]})
+		}
+		segments = append(segments, Segment{Text: text[loc[2]:loc[3]], Reading: text[loc[4]:loc[5]]})
+		last = loc[1]
+	}
+	if last < len(text) {
+		segments = append(segments, Segment{Text: text[last:]})
+	}
+	return segments
+}
+
+// Format 將標註結果輸出為 Anki 的振り仮名語法；
+// 漢字前面不是開頭或空白時加上空白，讓 Anki 判斷漢字部分的範圍
+func Format(segments []Segment) string {
+	var out strings.Builder
+	for _, segment := range segments {
+		if segment.Reading == "" {
+			out.WriteString(segment.Text)
+			continue
+		}
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), " ") {
+			out.WriteByte(' ')
+		}
+		out.WriteString(segment.Text + "[" + segment.Reading + "]")
+	}
+	return out.String()
+}
+
+// Strip 移除振り仮名標註，只保留原本的文字 (Anki 的 kanji 過濾器)
+func Strip(text string) string {
+	return annotationPattern.ReplaceAllString(text, "$1")
+}
+
+// Kana 以讀音取代標註的漢字 (Anki 的 kana 過濾器)
+func Kana(text string) string {
+	return annotationPattern.ReplaceAllString(text, "$2")
+}
+
+// Ruby 將振り仮名標註轉換為 HTML 的 <ruby> 標記 (Anki 的 furigana 過濾器)；其餘文字會跳脫
+func Ruby(text string) string {
+	var out strings.Builder
+	for _, segment := range Parse(text) {
+		if segment.Reading == "" {
+			out.WriteString(html.EscapeString(segment.Text))
+			continue
+		}
+		out.WriteString("<ruby><rb>" + html.EscapeString(segment.Text) + "</rb><rt>" + html.EscapeString(segment.Reading) + "</rt></ruby>")
+	}
+	return out.String()
+}
+
+// Align 將單字的讀音對應到各段漢字 (取り消す, とりけす → 取[と]り消[け]す)；
+// 送假名與讀音對不上時整個單字標註同一個讀音
+func Align(word, reading string) []Segment {
+	reading = japanese.ToHiragana(reading)
+
+	runs := splitRuns(word)
+	hasKanji := false
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for _, run := range runs {
+		if run.kanji {
+			hasKanji = true
+			pattern.WriteString("(.+?)")
+		} else {
+			pattern.WriteString("(" + regexp.QuoteMeta(japanese.ToHiragana(run.text)) + ")")
+		}
+	}
+	pattern.WriteString("$")
+	if !hasKanji {
+		return []Segment{{Text: word}}
+	}
+
+	match := regexp.MustCompile(pattern.String()).FindStringSubmatch(reading)
+	if match == nil {
+		return []Segment{{Text: word, Reading: reading}}
+	}
+
+	segments := make([]Segment, len(runs))
+	for i, run := range runs {
+		segments[i] = Segment{Text: run.text}
+		if run.kanji {
+			segments[i].Reading = match[i+1]
+		}
+	}
+	return segments
+}
+
+// run 單字中連續的漢字或非漢字
+type run struct {
+	text  string
+	kanji bool
+}
+
+// splitRuns 將單字分為漢字與非漢字的連續段落
+func splitRuns(word string) []run {
+	var runs []run
+	for _, r := range word {
+		kanji := japanese.IsKanji(r)
+		if n := len(runs); n > 0 && runs[n-1].kanji == kanji {
+			runs[n-1].text += string(r)
+			continue
+		}
+		runs = append(runs, run{text: string(r), kanji: kanji})
+	}
+	return runs
+}
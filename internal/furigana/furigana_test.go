@@ -0,0 +1,119 @@
+package furigana
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestAlign tests matching a reading to the kanji runs of a word
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		word     string
+		reading  string
+		expected string
+	}{
+		{"飲む", "のむ", "飲[の]む"},
+		{"取り消す", "とりけす", " 取[と]り 消[け]す"},
+		{"日本語", "にほんご", "日本語[にほんご]"},
+		{"お茶", "おちゃ", "お 茶[ちゃ]"},
+		{"飲む", "ノム", "飲[の]む"},
+		{"飲む", "たべる", "飲む[たべる]"},
+		{"ねこ", "ねこ", "ねこ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			got := Format(Align(tt.word, tt.reading))
+			if strings.TrimSpace(got) != strings.TrimSpace(tt.expected) {
+				t.Errorf("Align(%s, %s) = %q, expected %q", tt.word, tt.reading, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDictionary_Annotate tests annotating sentences with the embedded dictionary
+func TestDictionary_Annotate(t *testing.T) {
+	dict := Default()
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"寝る前に、温かい牛乳を飲む習慣があります。", "寝[ね]る 前[まえ]に、 温[あたた]かい 牛乳[ぎゅうにゅう]を 飲[の]む 習慣[しゅうかん]があります。"},
+		{"毎朝、和食を食べるのが好きです。", "毎朝[まいあさ]、 和食[わしょく]を 食[た]べるのが 好[す]きです。"},
+		{"水を飲みました", "水[みず]を 飲[の]みました"},
+		{"飲む", "飲[の]む"},
+		{"ひらがなだけ", "ひらがなだけ"},
+		{"未知の龘", "未知の龘"},
+		{"寝[ね]る", "寝[ね]る"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := dict.Annotate(tt.text); got != tt.expected {
+				t.Errorf("Annotate() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDictionary_AmbiguousStem tests that stems with several readings are not used
+func TestDictionary_AmbiguousStem(t *testing.T) {
+	dict := NewDictionary()
+	dict.Add("行く", "いく")
+	dict.Add("行う", "おこなう")
+
+	if got := dict.Annotate("行きます"); got != "行きます" {
+		t.Errorf("Annotate() = %q, expected the ambiguous stem to be left alone", got)
+	}
+	if got := dict.Annotate("行う"); got != "行[おこな]う" {
+		t.Errorf("Annotate() = %q, expected the full entry to be used", got)
+	}
+}
+
+// TestLoadFile tests that a dictionary file extends and overrides the embedded one
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dict.tsv")
+	content := "# comment\n\n猫\tびょう\n黒猫\tくろねこ\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dict, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if reading, _ := dict.Lookup("猫"); reading != "びょう" {
+		t.Errorf("Lookup(猫) = %q, expected the file to override the embedded reading", reading)
+	}
+	if got := dict.Annotate("黒猫と犬"); got != "黒猫[くろねこ]と 犬[いぬ]" {
+		t.Errorf("Annotate() = %q", got)
+	}
+
+	if err := os.WriteFile(path, []byte("猫\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("LoadFile() expected an error for a line without reading")
+	}
+}
+
+// TestRubyStripKana tests the previews and filters derived from the Anki syntax
+func TestRubyStripKana(t *testing.T) {
+	text := "寝[ね]る 前[まえ]に<b>"
+
+	if got := Ruby(text); got != "<ruby><rb>寝</rb><rt>ね</rt></ruby>る<ruby><rb>前</rb><rt>まえ</rt></ruby>に&lt;b&gt;" {
+		t.Errorf("Ruby() = %q", got)
+	}
+	if got := Strip(text); got != "寝る前に<b>" {
+		t.Errorf("Strip() = %q", got)
+	}
+	if got := Kana(text); got != "ねるまえに<b>" {
+		t.Errorf("Kana() = %q", got)
+	}
+	if !HasFurigana(text) || HasFurigana("寝る前に") {
+		t.Error("HasFurigana() returned an unexpected result")
+	}
+}
@@ -0,0 +1,113 @@
+package japanese
+
+import "testing"
+
+// TestDetect tests classifying the scripts used in a text
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected Script
+	}{
+		{"たべる", Hiragana},
+		{"コーヒー", Katakana},
+		{"ｺｰﾋｰ", Katakana},
+		{"食べる", Kanji | Hiragana},
+		{"人々", Kanji},
+		{"taberu", Romaji},
+		{"ｔａｂｅｒｕ", Romaji},
+		{"たべ ru", Hiragana | Romaji},
+		{"の。", Hiragana | Other},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := Detect(tt.text); got != tt.expected {
+				t.Errorf("Detect(%q) = %v, expected %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRomajiToHiragana tests Hepburn, Kunrei and IME spellings
+func TestRomajiToHiragana(t *testing.T) {
+	tests := []struct {
+		romaji   string
+		expected string
+		wantErr  bool
+	}{
+		{"taberu", "たべる", false},
+		{"Nomu", "のむ", false},
+		{"shinbun", "しんぶん", false},
+		{"sinbun", "しんぶん", false},
+		{"konnichiha", "こんにちは", false},
+		{"onna", "おんな", false},
+		{"hon", "ほん", false},
+		{"honn", "ほん", false},
+		{"kin'en", "きんえん", false},
+		{"kitte", "きって", false},
+		{"matcha", "まっちゃ", false},
+		{"tsukue", "つくえ", false},
+		{"kyou", "きょう", false},
+		{"tōkyō", "とうきょう", false},
+		{"ko-hi-", "こーひー", false},
+		{"ｔａｂｅｒｕ", "たべる", false},
+		{"たbeる", "たべる", false},
+		{"qwerty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.romaji, func(t *testing.T) {
+			got, err := RomajiToHiragana(tt.romaji)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RomajiToHiragana() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("RomajiToHiragana(%s) = %s, expected %s", tt.romaji, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNormalize tests width folding and Unicode normalisation
+func TestNormalize(t *testing.T) {
+	if got := NormalizeWidth("ﾀﾍﾞﾙ　ＡＢＣ１"); got != "タベル ABC1" {
+		t.Errorf("NormalizeWidth() = %q", got)
+	}
+	if got := NormalizeWidth("②"); got != "②" {
+		t.Errorf("NormalizeWidth() should keep circled numbers, got %q", got)
+	}
+	if got := NFC("か\u3099"); got != "が" {
+		t.Errorf("NFC() = %q, expected が", got)
+	}
+	if got := NFKC("②ｶ"); got != "2カ" {
+		t.Errorf("NFKC() = %q, expected 2カ", got)
+	}
+}
+
+// TestNormalizeReading tests the conversion applied to 發音
+func TestNormalizeReading(t *testing.T) {
+	tests := []struct {
+		reading  string
+		expected string
+		wantErr  bool
+	}{
+		{" のむ ", "のむ", false},
+		{"taberu", "たべる", false},
+		{"ﾀﾍﾞﾙ", "タベル", false},
+		{"食べる", "食べる", false},
+		{"xyz", "xyz", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reading, func(t *testing.T) {
+			got, err := NormalizeReading(tt.reading)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeReading() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("NormalizeReading(%q) = %q, expected %q", tt.reading, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package japanese
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// NFC 以 NFC 正規化文字 (合併分開輸入的濁點：か + ゙ → が)
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NFKC 以 NFKC 正規化文字：除了 NFC 外，也將半形片假名轉為全形、全形英數字轉為半形。
+// 注意 NFKC 也會轉換 ①、㌔ 等相容字元 (① → 1)
+func NFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// NormalizeWidth 統一全形與半形：全形英數字與符號轉為半形，半形片假名轉為全形 (ﾀﾍﾞﾙ → タベル)；
+// 其他相容字元 (①、㌔) 保持不變
+func NormalizeWidth(s string) string {
+	return NFC(width.Fold.String(s))
+}
+
+// NormalizeReading 正規化發音：統一全形/半形與 Unicode 形式、去除前後空白，
+// 並將羅馬拼音轉換為平假名 (taberu → たべる)。假名以外的字元 (例如漢字) 保持不變，
+// 由呼叫端檢查；羅馬拼音無法轉換時回傳錯誤
+func NormalizeReading(s string) (string, error) {
+	reading := strings.TrimSpace(NormalizeWidth(s))
+	if !ContainsRomaji(reading) {
+		return reading, nil
+	}
+	kana, err := RomajiToHiragana(reading)
+	if err != nil {
+		return reading, fmt.Errorf("發音: %w", err)
+	}
+	return kana, nil
+}
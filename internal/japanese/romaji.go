@@ -0,0 +1,127 @@
+package japanese
+
+import (
+	"fmt"
+	"strings"
+)
+
+// romajiTable 羅馬拼音與平假名的對照 (平文式、訓令式與日文輸入法的寫法)
+var romajiTable = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"sa": "さ", "si": "し", "shi": "し", "su": "す", "se": "せ", "so": "そ",
+	"ta": "た", "ti": "ち", "chi": "ち", "tu": "つ", "tsu": "つ", "te": "て", "to": "と",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"ha": "は", "hi": "ひ", "hu": "ふ", "fu": "ふ", "he": "へ", "ho": "ほ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"la": "ら", "li": "り", "lu": "る", "le": "れ", "lo": "ろ",
+	"wa": "わ", "wi": "うぃ", "we": "うぇ", "wo": "を",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"za": "ざ", "zi": "じ", "ji": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ", "sha": "しゃ", "shu": "しゅ", "sho": "しょ", "she": "しぇ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ", "cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ", "che": "ちぇ",
+	"cya": "ちゃ", "cyu": "ちゅ", "cyo": "ちょ",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"lya": "りゃ", "lyu": "りゅ", "lyo": "りょ",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ", "ja": "じゃ", "ju": "じゅ", "jo": "じょ", "je": "じぇ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"thi": "てぃ", "dhi": "でぃ", "twu": "とぅ", "dwu": "どぅ",
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ", "xtu": "っ", "xtsu": "っ", "ltu": "っ", "ltsu": "っ",
+}
+
+// longVowels 將平文式的長音母音 (ā、ō 等) 展開為兩個母音；ō 依日文習慣寫作 ou (おう)
+var longVowels = strings.NewReplacer(
+	"ā", "aa", "ī", "ii", "ū", "uu", "ē", "ee", "ō", "ou",
+	"â", "aa", "î", "ii", "û", "uu", "ê", "ee", "ô", "ou",
+)
+
+// maxRomajiLen 對照表中最長的拼音長度
+const maxRomajiLen = 4
+
+// RomajiToHiragana 將羅馬拼音轉換為平假名 (taberu → たべる)。
+// 支援平文式 (shi、tsu、fu)、訓令式 (si、tu、hu) 與輸入法的寫法 (nn、xtu)；
+// 長音母音 (ō) 展開為兩個母音，重複的子音轉換為っ (kitte → きって)，
+// n 後面不是母音或 y 時為ん (n' 可明確分隔)，- 轉換為長音符號ー。假名與其他字元保持不變，無法轉換的拼音回傳錯誤
+func RomajiToHiragana(s string) (string, error) {
+	runes := []rune(longVowels.Replace(strings.ToLower(NormalizeWidth(s))))
+	var out strings.Builder
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if !isASCIILetter(r) {
+			if r == '-' {
+				out.WriteRune('ー')
+			} else if r != '\'' {
+				out.WriteRune(r)
+			}
+			i++
+			continue
+		}
+
+		// ん：n 後面不是母音或 y (nn 視為一個ん，除非後面接著母音)
+		if r == 'n' && !startsSyllable(runes, i+1) {
+			out.WriteRune('ん')
+			i++
+			if i < len(runes) && runes[i] == 'n' && !startsSyllable(runes, i+1) {
+				i++
+			}
+			continue
+		}
+
+		// 促音：重複的子音 (kk、tt、ss…) 與 tch
+		if i+1 < len(runes) && r != 'n' && !isVowel(r) && isASCIILetter(runes[i+1]) &&
+			(runes[i+1] == r || (r == 't' && runes[i+1] == 'c')) {
+			out.WriteRune('っ')
+			i++
+			continue
+		}
+
+		kana, n := matchRomaji(runes[i:])
+		if n == 0 {
+			return "", fmt.Errorf("無法將「%s」轉換為假名: %s", s, string(runes[i:]))
+		}
+		out.WriteString(kana)
+		i += n
+	}
+	return out.String(), nil
+}
+
+// matchRomaji 以最長比對找出開頭的拼音，回傳平假名與使用的字數
+func matchRomaji(runes []rune) (string, int) {
+	for n := min(maxRomajiLen, len(runes)); n > 0; n-- {
+		if kana, ok := romajiTable[string(runes[:n])]; ok {
+			return kana, n
+		}
+	}
+	return "", 0
+}
+
+// startsSyllable 檢查 n 後面的字元是否與 n 組成音節 (母音或 y)
+func startsSyllable(runes []rune, i int) bool {
+	return i < len(runes) && (isVowel(runes[i]) || runes[i] == 'y')
+}
+
+// isVowel 檢查是否為母音字母
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aiueo", r)
+}
+
+// isASCIILetter 檢查是否為半形英文字母
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
@@ -0,0 +1,134 @@
+// Package japanese 提供日文文字的工具：判斷平假名、片假名、漢字與羅馬拼音，
+// 將羅馬拼音轉換為平假名，以及全形/半形與 Unicode 正規化 (NFC/NFKC)
+package japanese
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Script 文字中出現的字元種類 (可組合)
+type Script int
+
+const (
+	Hiragana Script = 1 << iota // 平假名
+	Katakana                    // 片假名 (包含長音符號ー)
+	Kanji                       // 漢字 (包含々)
+	Romaji                      // 羅馬拼音 (拉丁字母)
+	Other                       // 其他字元 (數字、標點符號等，不含空白)
+)
+
+// Has 檢查是否包含指定的字元種類
+func (s Script) Has(script Script) bool {
+	return s&script != 0
+}
+
+// String 回傳字元種類的名稱 (以 + 連接)
+func (s Script) String() string {
+	var names []string
+	for _, item := range []struct {
+		script Script
+		name   string
+	}{
+		{Hiragana, "平假名"}, {Katakana, "片假名"}, {Kanji, "漢字"}, {Romaji, "羅馬拼音"}, {Other, "其他"},
+	} {
+		if s.Has(item.script) {
+			names = append(names, item.name)
+		}
+	}
+	if len(names) == 0 {
+		return "無"
+	}
+	return strings.Join(names, "+")
+}
+
+// IsHiragana 檢查字元是否為平假名
+func IsHiragana(r rune) bool {
+	return unicode.Is(unicode.Hiragana, r)
+}
+
+// IsKatakana 檢查字元是否為片假名 (包含半形片假名與長音符號ー)
+func IsKatakana(r rune) bool {
+	return unicode.Is(unicode.Katakana, r) || r == 'ー' || r == 'ｰ'
+}
+
+// IsKana 檢查字元是否為假名
+func IsKana(r rune) bool {
+	return IsHiragana(r) || IsKatakana(r)
+}
+
+// IsKanji 檢查字元是否為漢字 (包含々)
+func IsKanji(r rune) bool {
+	return unicode.Is(unicode.Han, r)
+}
+
+// IsRomaji 檢查字元是否為拉丁字母 (包含全形字母與長音的母音符號，例如 ō)
+func IsRomaji(r rune) bool {
+	return unicode.Is(unicode.Latin, r)
+}
+
+// Detect 回傳文字中出現的字元種類；空白不計入
+func Detect(s string) Script {
+	var script Script
+	for _, r := range s {
+		switch {
+		case IsHiragana(r):
+			script |= Hiragana
+		case IsKatakana(r):
+			script |= Katakana
+		case IsKanji(r):
+			script |= Kanji
+		case IsRomaji(r):
+			script |= Romaji
+		case unicode.IsSpace(r):
+		default:
+			script |= Other
+		}
+	}
+	return script
+}
+
+// ContainsKanji 檢查文字是否包含漢字
+func ContainsKanji(s string) bool {
+	return strings.IndexFunc(s, IsKanji) >= 0
+}
+
+// ContainsRomaji 檢查文字是否包含拉丁字母
+func ContainsRomaji(s string) bool {
+	return strings.IndexFunc(s, IsRomaji) >= 0
+}
+
+// IsKanaText 檢查文字是否只包含假名 (忽略空白與・)，空字串回傳 false
+func IsKanaText(s string) bool {
+	hasKana := false
+	for _, r := range s {
+		switch {
+		case IsKana(r):
+			hasKana = true
+		case r == '・' || unicode.IsSpace(r):
+		default:
+			return false
+		}
+	}
+	return hasKana
+}
+
+// ToHiragana 將片假名轉換為平假名 (ー 等沒有對應平假名的字元保持不變)
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 'ァ' + 'ぁ'
+		}
+		return r
+	}, s)
+}
+
+// ToKatakana 將平假名轉換為片假名
+func ToKatakana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r - 'ぁ' + 'ァ'
+		}
+		return r
+	}, s)
+}
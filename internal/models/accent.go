@@ -0,0 +1,30 @@
+package models
+
+import (
+	"anki-japanese-cli/internal/pitch"
+)
+
+// validateAccent 檢查重音標記的格式，並以發音的拍數檢查下降位置。
+// 未填寫重音、無法解析的標記 (重音原本可自由填寫) 與無法換算的類型名稱只回傳警告；
+// 數字標記超過發音的拍數時回傳錯誤。發音無法分拍時 (例如包含漢字) 只檢查格式
+func validateAccent(accent, reading string) *ValidationError {
+	if accent == "" {
+		return NewValidationWarning("重音", "建議填寫")
+	}
+	_, named := pitch.ParsePattern(accent)
+	if !named {
+		if _, err := pitch.ParsePositions(accent); err != nil {
+			return NewValidationWarning("重音", err.Error())
+		}
+	}
+	if moras, err := pitch.Moras(reading); err != nil || len(moras) == 0 {
+		return nil
+	}
+	if _, err := pitch.Parse(accent, reading); err != nil {
+		if named {
+			return NewValidationWarning("重音", err.Error())
+		}
+		return NewValidationError("重音", err.Error())
+	}
+	return nil
+}
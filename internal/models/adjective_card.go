@@ -1,6 +1,11 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+
+	"anki-japanese-cli/internal/conjugation"
+)
 
 // AdjectiveCard 形容詞卡片類型
 type AdjectiveCard struct {
@@ -13,6 +18,7 @@ type AdjectiveCard struct {
 	ContextSentence string `json:"情境例句"`
 	Translation     string `json:"例句翻譯"`
 	RelatedWords    string `json:"相關詞彙,omitempty"`
+	Audio           string `json:"音檔,omitempty"`
 }
 
 // GetCardType 返回卡片類型
@@ -21,23 +27,86 @@ func (a *AdjectiveCard) GetCardType() string {
 }
 
 // Validate 驗證卡片資料
-func (a *AdjectiveCard) Validate() error {
+func (a *AdjectiveCard) Validate() ValidationErrors {
+	var errs ValidationErrors
 	if a.CoreWord == "" {
-		return NewValidationError("核心單字", "不能為空")
+		errs = append(errs, NewValidationError("核心單字", "不能為空"))
 	}
 	if a.CoreMeaning == "" {
-		return NewValidationError("核心意義", "不能為空")
+		errs = append(errs, NewValidationError("核心意義", "不能為空"))
 	}
-	if a.Pronunciation == "" {
-		return NewValidationError("發音", "不能為空")
+	if err := validateReading(a.Pronunciation); err != nil {
+		errs = append(errs, err)
 	}
 	if a.ContextSentence == "" {
-		return NewValidationError("情境例句", "不能為空")
+		errs = append(errs, NewValidationError("情境例句", "不能為空"))
 	}
 	if a.Translation == "" {
-		return NewValidationError("例句翻譯", "不能為空")
+		errs = append(errs, NewValidationError("例句翻譯", "不能為空"))
+	}
+	if a.WordType == "" {
+		errs = append(errs, NewValidationError("詞性分類", "不能為空"))
+	} else if class, err := conjugation.ClassifyAdjective(a.WordType); err != nil {
+		errs = append(errs, NewValidationError("詞性分類", "必須是 い形容詞 或 な形容詞"))
+	} else if a.CoreWord != "" {
+		if _, err := conjugation.InflectAdjective(a.CoreWord, class); err != nil {
+			errs = append(errs, NewValidationError("詞性分類", fmt.Sprintf("與核心單字不符: %v", err)))
+		}
+	}
+	if err := validateAccent(a.Accent, a.Pronunciation); err != nil {
+		errs = append(errs, err)
+	}
+	for _, mismatch := range a.InflectionMismatches() {
+		errs = append(errs, NewValidationWarning("主要變化",
+			fmt.Sprintf("%s應為「%s」(目前為「%s」)", mismatch.Name, mismatch.Expected, mismatch.Got)))
+	}
+	return errs
+}
+
+// ExpectedInflections 由核心單字與詞性分類產生形容詞的主要變化；
+// 第二個回傳值為發音的變化 (用於比對以假名書寫的主要變化，發音為空時為 nil)
+func (a *AdjectiveCard) ExpectedInflections() (conjugation.Forms, conjugation.Forms, error) {
+	class, err := conjugation.ClassifyAdjective(a.WordType)
+	if err != nil {
+		return nil, nil, err
+	}
+	forms, err := conjugation.InflectAdjective(a.CoreWord, class)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var kana conjugation.Forms
+	if a.Pronunciation != "" && a.Pronunciation != a.CoreWord {
+		kana, _ = conjugation.InflectAdjective(a.Pronunciation, class)
+	}
+	return forms, kana, nil
+}
+
+// InflectionMismatches 比對主要變化與產生的變化；
+// 主要變化為空或無法判斷形容詞種類時不比對
+func (a *AdjectiveCard) InflectionMismatches() []conjugation.Mismatch {
+	if a.MainChanges == "" {
+		return nil
+	}
+	forms, kana, err := a.ExpectedInflections()
+	if err != nil {
+		return nil
+	}
+	return conjugation.Verify(a.MainChanges, forms, kana)
+}
+
+// FillInflections 主要變化為空時填入產生的變化；fix 為 true 時也取代不一致的主要變化。
+// 回傳主要變化是否被修改
+func (a *AdjectiveCard) FillInflections(fix bool) (bool, error) {
+	forms, _, err := a.ExpectedInflections()
+	if err != nil {
+		return false, err
+	}
+	if a.MainChanges != "" && !(fix && len(a.InflectionMismatches()) > 0) {
+		return false, nil
 	}
-	return nil
+	a.MainChanges = forms.String()
+	return true, nil
 }
 
 // ToMap 轉換為 map 格式
@@ -52,6 +121,7 @@ func (a *AdjectiveCard) ToMap() map[string]interface{} {
 		"情境例句": a.ContextSentence,
 		"例句翻譯": a.Translation,
 		"相關詞彙": a.RelatedWords,
+		"音檔":   a.Audio,
 	}
 }
 
@@ -2,6 +2,8 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -89,18 +91,44 @@ func TestAdjectiveCard_Validate(t *testing.T) {
 			wantErr:  true,
 			errField: "例句翻譯",
 		},
+		{
+			name: "Unrecognised WordType",
+			card: AdjectiveCard{
+				CoreWord:        "美しい",
+				WordType:        "名詞",
+				CoreMeaning:     "美麗的",
+				Pronunciation:   "うつくしい",
+				ContextSentence: "美しい花",
+				Translation:     "美麗的花",
+			},
+			wantErr:  true,
+			errField: "詞性分類",
+		},
+		{
+			name: "い形容詞 not ending in い",
+			card: AdjectiveCard{
+				CoreWord:        "静か",
+				WordType:        "い形容詞",
+				CoreMeaning:     "安靜的",
+				Pronunciation:   "しずか",
+				ContextSentence: "静かな部屋",
+				Translation:     "安靜的房間",
+			},
+			wantErr:  true,
+			errField: "詞性分類",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.card.Validate()
+			err := tt.card.Validate().Err()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AdjectiveCard.Validate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil {
-				validationErr, ok := err.(*ValidationError)
-				if !ok {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
 					t.Errorf("Expected ValidationError, got %T", err)
 					return
 				}
@@ -231,3 +259,23 @@ func TestAdjectiveCard_JSONSerialization(t *testing.T) {
 		t.Errorf("JSON serialization/deserialization failed. Original: %v, Deserialized: %v", original, deserialized)
 	}
 }
+
+func TestAdjectiveCard_FillInflections(t *testing.T) {
+	card := AdjectiveCard{CoreWord: "静か", WordType: "な形容詞", Pronunciation: "しずか"}
+	changed, err := card.FillInflections(false)
+	if err != nil || !changed {
+		t.Fatalf("FillInflections() = %v, %v, expected the empty field to be filled", changed, err)
+	}
+	if !strings.HasPrefix(card.MainChanges, "否定形: 静かではない<br>過去形: 静かだった") {
+		t.Errorf("MainChanges = %q", card.MainChanges)
+	}
+
+	card.MainChanges = "否定形: しずかじゃない<br>過去形: 静かかった"
+	mismatches := card.InflectionMismatches()
+	if len(mismatches) != 1 || mismatches[0].Expected != "静かだった" {
+		t.Errorf("InflectionMismatches() = %v, expected only the 過去形 to be reported", mismatches)
+	}
+	if changed, _ := card.FillInflections(true); !changed {
+		t.Error("FillInflections(true) should replace inconsistent inflections")
+	}
+}
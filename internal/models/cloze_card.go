@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ClozeCard 克漏字卡片類型 (Anki 的 Cloze 模型)
+type ClozeCard struct {
+	Text        string `json:"克漏字"`
+	Translation string `json:"例句翻譯"`
+	Source      string `json:"出處,omitempty"`
+	Notes       string `json:"備註,omitempty"`
+}
+
+// clozePattern 比對 Anki 的克漏字語法 {{c1::答案}} 或 {{c1::答案::提示}}
+var clozePattern = regexp.MustCompile(`{{c(\d+)::(.*?)(?:::(.*?))?}}`)
+
+// GetCardType 返回卡片類型
+func (c *ClozeCard) GetCardType() string {
+	return "cloze"
+}
+
+// Validate 驗證卡片資料
+func (c *ClozeCard) Validate() ValidationErrors {
+	var errs ValidationErrors
+	if c.Text == "" {
+		errs = append(errs, NewValidationError("克漏字", "不能為空"))
+	} else if err := ValidateCloze(c.Text); err != nil {
+		errs = append(errs, NewValidationError("克漏字", err.Error()))
+	}
+	if c.Translation == "" {
+		errs = append(errs, NewValidationWarning("例句翻譯", "建議填寫"))
+	}
+	return errs
+}
+
+// ToMap 轉換為 map 格式
+func (c *ClozeCard) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"克漏字":  c.Text,
+		"例句翻譯": c.Translation,
+		"出處":   c.Source,
+		"備註":   c.Notes,
+	}
+}
+
+// FromMap 從 map 載入資料
+func (c *ClozeCard) FromMap(data map[string]interface{}) error {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, c)
+}
+
+// ValidateCloze 檢查文字是否包含至少一個格式正確的克漏字
+func ValidateCloze(text string) error {
+	matches := clozePattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return fmt.Errorf("必須包含至少一個克漏字 (例如 {{c1::答案}})")
+	}
+
+	for _, match := range matches {
+		if number, err := strconv.Atoi(match[1]); err != nil || number < 1 {
+			return fmt.Errorf("克漏字編號必須從 1 開始: %s", match[0])
+		}
+		if strings.TrimSpace(match[2]) == "" {
+			return fmt.Errorf("克漏字的答案不能為空: %s", match[0])
+		}
+	}
+
+	// 移除格式正確的克漏字後不應再有克漏字的標記
+	rest := clozePattern.ReplaceAllString(text, "")
+	if strings.Contains(rest, "{{c") {
+		return fmt.Errorf("克漏字格式錯誤 (應為 {{c1::答案}} 或 {{c1::答案::提示}})")
+	}
+	return nil
+}
+
+// MakeCloze 將句子中的單字替換為 {{c1::單字}} (hint 不為空時加上提示)。
+// 句子中找不到原形時 (例如動詞已變化)，改以單字的漢字語幹比對
+func MakeCloze(sentence, word, hint string) (string, error) {
+	if sentence == "" || word == "" {
+		return "", fmt.Errorf("句子與單字不能為空")
+	}
+
+	target := word
+	if !strings.Contains(sentence, target) {
+		target = wordStem(word)
+		if target == "" || !strings.Contains(sentence, target) {
+			return "", fmt.Errorf("例句中找不到 '%s'", word)
+		}
+	}
+
+	deletion := "{{c1::" + target
+	if hint != "" {
+		deletion += "::" + hint
+	}
+	deletion += "}}"
+	return strings.Replace(sentence, target, deletion, 1), nil
+}
+
+// wordStem 去除單字結尾的平假名 (送假名)，只在語幹包含漢字時回傳
+func wordStem(word string) string {
+	stem := strings.TrimRightFunc(word, func(r rune) bool {
+		return unicode.Is(unicode.Hiragana, r)
+	})
+	for _, r := range stem {
+		if unicode.Is(unicode.Han, r) {
+			return stem
+		}
+	}
+	return ""
+}
+
+// NewClozeCardFromWord 由動詞或一般單字卡片產生克漏字卡片：
+// 將情境例句中的核心單字挖空，並以核心意義作為提示
+func NewClozeCardFromWord(card CardType) (*ClozeCard, error) {
+	var word, meaning, pronunciation, sentence, translation string
+	switch c := card.(type) {
+	case *VerbCard:
+		word, meaning, pronunciation, sentence, translation = c.CoreWord, c.CoreMeaning, c.Pronunciation, c.ContextSentence, c.Translation
+	case *NormalWordCard:
+		word, meaning, pronunciation, sentence, translation = c.CoreWord, c.CoreMeaning, c.Pronunciation, c.ContextSentence, c.Translation
+	default:
+		return nil, fmt.Errorf("無法由 %s 卡片產生克漏字卡片", card.GetCardType())
+	}
+
+	text, err := MakeCloze(sentence, word, meaning)
+	if err != nil {
+		return nil, ValidationErrors{NewValidationError("情境例句", err.Error())}
+	}
+
+	return &ClozeCard{
+		Text:        text,
+		Translation: translation,
+		Notes:       fmt.Sprintf("%s (%s): %s", word, pronunciation, meaning),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClozeCard_GetCardType(t *testing.T) {
+	card := &ClozeCard{}
+	if card.GetCardType() != "cloze" {
+		t.Errorf("Expected card type to be 'cloze', got '%s'", card.GetCardType())
+	}
+}
+
+func TestValidateCloze(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		wantErr bool
+	}{
+		{"Single deletion", "水を{{c1::飲む}}", false},
+		{"Deletion with hint", "水を{{c1::飲む::喝}}", false},
+		{"Several deletions", "{{c1::毎朝}}水を{{c2::飲む}}", false},
+		{"No deletion", "水を飲む", true},
+		{"Empty answer", "水を{{c1::}}", true},
+		{"Zero index", "水を{{c0::飲む}}", true},
+		{"Unclosed deletion", "水を{{c1::飲む}", true},
+		{"Missing separator", "水を{{c1::飲む}}と{{c2:食べる}}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCloze(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCloze(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClozeCard_Validate(t *testing.T) {
+	card := ClozeCard{Text: "水を飲む"}
+	errs := card.Validate()
+
+	var validationErr *ValidationError
+	if !errors.As(errs.Err(), &validationErr) || validationErr.Field != "克漏字" {
+		t.Errorf("Validate() error = %v, expected an error for 克漏字", errs.Err())
+	}
+	if warnings := errs.Warnings(); len(warnings) != 1 || warnings[0].Field != "例句翻譯" {
+		t.Errorf("Validate() warnings = %v, expected a warning for 例句翻譯", warnings)
+	}
+}
+
+func TestMakeCloze(t *testing.T) {
+	tests := []struct {
+		name     string
+		sentence string
+		word     string
+		hint     string
+		want     string
+		wantErr  bool
+	}{
+		{"Dictionary form", "寝る前に牛乳を飲む。", "飲む", "", "寝る前に牛乳を{{c1::飲む}}。", false},
+		{"With hint", "寝る前に牛乳を飲む。", "飲む", "喝", "寝る前に牛乳を{{c1::飲む::喝}}。", false},
+		{"Conjugated verb", "牛乳を飲んでいます。", "飲む", "", "牛乳を{{c1::飲}}んでいます。", false},
+		{"Only the first occurrence", "本を読む。本が好き。", "本", "", "{{c1::本}}を読む。本が好き。", false},
+		{"Kana word not found", "とても大きい。", "すごい", "", "", true},
+		{"Empty sentence", "", "飲む", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MakeCloze(tt.sentence, tt.word, tt.hint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MakeCloze() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MakeCloze() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCardFactory_CreateClozeFromCard(t *testing.T) {
+	factory := NewCardFactory()
+
+	card, err := factory.CreateClozeFromCard("verb", map[string]interface{}{
+		"核心單字": "飲む",
+		"核心意義": "喝",
+		"發音":   "のむ",
+		"情境例句": "寝る前に、温かい牛乳を飲む習慣があります。",
+		"例句翻譯": "我有睡前喝溫牛奶的習慣。",
+	})
+	if err != nil {
+		t.Fatalf("CreateClozeFromCard() error = %v", err)
+	}
+	if card.Text != "寝る前に、温かい牛乳を{{c1::飲む::喝}}習慣があります。" {
+		t.Errorf("Text = %q", card.Text)
+	}
+	if card.Translation != "我有睡前喝溫牛奶的習慣。" || !strings.Contains(card.Notes, "のむ") {
+		t.Errorf("CreateClozeFromCard() = %+v", card)
+	}
+	if errs := card.Validate(); errs.HasErrors() {
+		t.Errorf("generated card is invalid: %v", errs)
+	}
+
+	// The source card is validated first
+	if _, err := factory.CreateClozeFromCard("normal", map[string]interface{}{"核心單字": "本"}); err == nil {
+		t.Error("CreateClozeFromCard() expected a validation error for an incomplete source card")
+	}
+
+	if _, err := factory.CreateClozeFromCard("grammar", map[string]interface{}{}); err == nil {
+		t.Error("CreateClozeFromCard() expected an error for an unsupported source type")
+	}
+}
@@ -1,17 +1,40 @@
 package models
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // CardType 卡片類型介面
 type CardType interface {
 	GetCardType() string
-	Validate() error
+	// Validate 一次回傳所有驗證問題 (包含警告)，以 Err() 取得必須修正的錯誤
+	Validate() ValidationErrors
+}
+
+// Severity 驗證問題的嚴重程度
+type Severity int
+
+const (
+	// SeverityError 必須修正，卡片無法新增
+	SeverityError Severity = iota
+	// SeverityWarning 建議修正，不影響新增
+	SeverityWarning
+)
+
+// String 返回嚴重程度的名稱
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "警告"
+	}
+	return "錯誤"
 }
 
 // ValidationError 驗證錯誤類型
 type ValidationError struct {
-	Field   string
-	Message string
+	Field    string
+	Message  string
+	Severity Severity
 }
 
 // Error 實作錯誤介面
@@ -22,9 +45,75 @@ func (e *ValidationError) Error() string {
 // NewValidationError 建立新的驗證錯誤
 func NewValidationError(field, message string) *ValidationError {
 	return &ValidationError{
-		Field:   field,
-		Message: message,
+		Field:    field,
+		Message:  message,
+		Severity: SeverityError,
+	}
+}
+
+// NewValidationWarning 建立新的驗證警告
+func NewValidationWarning(field, message string) *ValidationError {
+	return &ValidationError{
+		Field:    field,
+		Message:  message,
+		Severity: SeverityWarning,
+	}
+}
+
+// ValidationErrors 一張卡片的所有驗證問題 (錯誤與警告)
+type ValidationErrors []*ValidationError
+
+// Error 實作錯誤介面
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
 	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap 讓 errors.As 可以取得個別的 ValidationError
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Errors 返回必須修正的錯誤
+func (e ValidationErrors) Errors() ValidationErrors {
+	return e.filter(SeverityError)
+}
+
+// Warnings 返回不影響新增的警告
+func (e ValidationErrors) Warnings() ValidationErrors {
+	return e.filter(SeverityWarning)
+}
+
+// HasErrors 是否包含必須修正的錯誤
+func (e ValidationErrors) HasErrors() bool {
+	return len(e.Errors()) > 0
+}
+
+// Err 沒有錯誤時回傳 nil，否則回傳只包含錯誤 (不含警告) 的集合
+func (e ValidationErrors) Err() error {
+	errs := e.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// filter 返回指定嚴重程度的問題
+func (e ValidationErrors) filter(severity Severity) ValidationErrors {
+	var filtered ValidationErrors
+	for _, err := range e {
+		if err.Severity == severity {
+			filtered = append(filtered, err)
+		}
+	}
+	return filtered
 }
 
 // CardData 通用卡片資料介面
@@ -32,3 +121,9 @@ type CardData interface {
 	ToMap() map[string]interface{}
 	FromMap(data map[string]interface{}) error
 }
+
+// Card 可驗證並與卡片資料互相轉換的卡片
+type Card interface {
+	CardType
+	CardData
+}
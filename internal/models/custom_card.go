@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CustomCard 由 YAML 定義的自訂卡片類型
+type CustomCard struct {
+	definition *CardTypeDefinition
+	Fields     map[string]string
+}
+
+// NewCustomCard 建立指定定義的空白自訂卡片
+func NewCustomCard(def *CardTypeDefinition) *CustomCard {
+	return &CustomCard{
+		definition: def,
+		Fields:     make(map[string]string, len(def.Fields)),
+	}
+}
+
+// GetCardType 返回卡片類型
+func (c *CustomCard) GetCardType() string {
+	return c.definition.Name
+}
+
+// Validate 驗證卡片資料
+func (c *CustomCard) Validate() ValidationErrors {
+	var errs ValidationErrors
+	for _, field := range c.definition.Required {
+		if c.Fields[field] == "" {
+			errs = append(errs, NewValidationError(field, "不能為空"))
+		}
+	}
+	return errs
+}
+
+// ToMap 轉換為 map
+func (c *CustomCard) ToMap() map[string]interface{} {
+	data := make(map[string]interface{}, len(c.Fields))
+	for name, value := range c.Fields {
+		data[name] = value
+	}
+	return data
+}
+
+// FromMap 從 map 載入資料，非字串的值會轉為字串
+func (c *CustomCard) FromMap(data map[string]interface{}) error {
+	for name, value := range data {
+		switch v := value.(type) {
+		case nil:
+			c.Fields[name] = ""
+		case string:
+			c.Fields[name] = v
+		case float64, bool, json.Number:
+			c.Fields[name] = fmt.Sprint(v)
+		default:
+			return fmt.Errorf("欄位 '%s' 的值必須是字串", name)
+		}
+	}
+	return nil
+}
+
+// MarshalJSON 以欄位名稱輸出卡片內容
+func (c *CustomCard) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Fields)
+}
@@ -7,107 +7,98 @@ import (
 )
 
 // CardFactory 卡片工廠
-type CardFactory struct{}
+type CardFactory struct {
+	registry *Registry
+}
 
-// NewCardFactory 建立新的卡片工廠
+// NewCardFactory 建立使用預設登錄表 (內建類型與已載入的自訂類型) 的卡片工廠
 func NewCardFactory() *CardFactory {
-	return &CardFactory{}
+	return NewCardFactoryWithRegistry(DefaultRegistry())
 }
 
-// CreateCard 根據類型和資料建立卡片
-func (cf *CardFactory) CreateCard(cardType string, data map[string]interface{}) (CardType, error) {
-	switch cardType {
-	case "verb":
-		return cf.createVerbCard(data)
-	case "adjective":
-		return cf.createAdjectiveCard(data)
-	case "normal":
-		return cf.createNormalCard(data)
-	case "grammar":
-		return cf.createGrammarCard(data)
-	default:
-		return nil, fmt.Errorf("不支援的卡片類型: %s", cardType)
-	}
+// NewCardFactoryWithRegistry 建立使用指定登錄表的卡片工廠
+func NewCardFactoryWithRegistry(registry *Registry) *CardFactory {
+	return &CardFactory{registry: registry}
 }
 
-// CreateCardFromJSON 從 JSON 資料建立卡片
-func (cf *CardFactory) CreateCardFromJSON(cardType string, jsonData []byte) (CardType, error) {
-	var data map[string]interface{}
-	err := json.Unmarshal(jsonData, &data)
-	if err != nil {
-		return nil, fmt.Errorf("JSON 解析失敗: %w", err)
-	}
-
-	return cf.CreateCard(cardType, data)
+// Definition 取得卡片類型定義
+func (cf *CardFactory) Definition(cardType string) (*CardTypeDefinition, bool) {
+	return cf.registry.Get(cardType)
 }
 
-// createVerbCard 建立動詞卡片
-func (cf *CardFactory) createVerbCard(data map[string]interface{}) (*VerbCard, error) {
-	card := &VerbCard{}
+// CreateCard 根據類型和資料建立卡片；所有類型 (內建與自訂) 都由登錄表中的定義建立
+func (cf *CardFactory) CreateCard(cardType string, data map[string]interface{}) (CardType, error) {
+	def, ok := cf.registry.Get(cardType)
+	if !ok {
+		return nil, fmt.Errorf("不支援的卡片類型: %s", cardType)
+	}
+
+	card := def.NewCard()
 	err := card.FromMap(data)
 	if err != nil {
-		return nil, fmt.Errorf("建立動詞卡片失敗: %w", err)
+		return nil, fmt.Errorf("建立%s卡片失敗: %w", def.label(), err)
 	}
 
-	err = card.Validate()
+	err = card.Validate().Err()
 	if err != nil {
-		return nil, fmt.Errorf("動詞卡片驗證失敗: %w", err)
+		return nil, fmt.Errorf("%s卡片驗證失敗: %w", def.label(), err)
 	}
 
 	return card, nil
 }
 
-// createAdjectiveCard 建立形容詞卡片
-func (cf *CardFactory) createAdjectiveCard(data map[string]interface{}) (*AdjectiveCard, error) {
-	card := &AdjectiveCard{}
-	err := card.FromMap(data)
+// CreateCardFromJSON 從 JSON 資料建立卡片
+func (cf *CardFactory) CreateCardFromJSON(cardType string, jsonData []byte) (CardType, error) {
+	var data map[string]interface{}
+	err := json.Unmarshal(jsonData, &data)
 	if err != nil {
-		return nil, fmt.Errorf("建立形容詞卡片失敗: %w", err)
+		return nil, fmt.Errorf("JSON 解析失敗: %w", err)
 	}
 
-	err = card.Validate()
-	if err != nil {
-		return nil, fmt.Errorf("形容詞卡片驗證失敗: %w", err)
-	}
+	return cf.CreateCard(cardType, data)
+}
 
-	return card, nil
+// FieldNames 取得卡片類型的欄位名稱 (即 Anki 模型的欄位)
+func (cf *CardFactory) FieldNames(cardType string) ([]string, error) {
+	def, ok := cf.registry.Get(cardType)
+	if !ok {
+		return nil, fmt.Errorf("不支援的卡片類型: %s", cardType)
+	}
+	return append([]string(nil), def.Fields...), nil
 }
 
-// createNormalCard 建立一般單字卡片
-func (cf *CardFactory) createNormalCard(data map[string]interface{}) (*NormalWordCard, error) {
-	card := &NormalWordCard{}
-	err := card.FromMap(data)
-	if err != nil {
-		return nil, fmt.Errorf("建立一般單字卡片失敗: %w", err)
+// DecodeCard 將資料載入對應類型的卡片但不進行驗證 (用於讀回 Anki 中既有的筆記)
+func (cf *CardFactory) DecodeCard(cardType string, data map[string]interface{}) (CardType, error) {
+	def, ok := cf.registry.Get(cardType)
+	if !ok {
+		return nil, fmt.Errorf("不支援的卡片類型: %s", cardType)
 	}
 
-	err = card.Validate()
-	if err != nil {
-		return nil, fmt.Errorf("一般單字卡片驗證失敗: %w", err)
+	card := def.NewCard()
+	if err := card.FromMap(data); err != nil {
+		return nil, fmt.Errorf("載入卡片資料失敗: %w", err)
 	}
 
 	return card, nil
 }
 
-// createGrammarCard 建立文法卡片
-func (cf *CardFactory) createGrammarCard(data map[string]interface{}) (*GrammarCard, error) {
-	card := &GrammarCard{}
-	err := card.FromMap(data)
-	if err != nil {
-		return nil, fmt.Errorf("建立文法卡片失敗: %w", err)
+// CreateClozeFromCard 由動詞或一般單字卡片的資料產生克漏字卡片 (來源資料會先經過驗證)
+func (cf *CardFactory) CreateClozeFromCard(sourceType string, data map[string]interface{}) (*ClozeCard, error) {
+	if sourceType != "verb" && sourceType != "normal" {
+		return nil, fmt.Errorf("只能由 verb 或 normal 卡片產生克漏字卡片: %s", sourceType)
 	}
 
-	err = card.Validate()
+	card, err := cf.CreateCard(sourceType, data)
 	if err != nil {
-		return nil, fmt.Errorf("文法卡片驗證失敗: %w", err)
+		return nil, err
 	}
 
-	return card, nil
+	return NewClozeCardFromWord(card)
 }
 
 // GetSupportedCardTypes 獲取支援的卡片類型
 func (cf *CardFactory) GetSupportedCardTypes() []string {
-	return []string{"verb", "adjective", "normal", "grammar"}
+	return cf.registry.Names()
 }
 
 // ValidateCardType 驗證卡片類型是否支援
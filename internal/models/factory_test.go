@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -15,7 +16,7 @@ func TestCardFactory_GetSupportedCardTypes(t *testing.T) {
 	factory := NewCardFactory()
 	types := factory.GetSupportedCardTypes()
 
-	expected := []string{"verb", "adjective", "normal", "grammar"}
+	expected := []string{"verb", "adjective", "normal", "grammar", "kanji", "cloze"}
 	if len(types) != len(expected) {
 		t.Errorf("GetSupportedCardTypes() returned %d types, expected %d", len(types), len(expected))
 	}
@@ -39,7 +40,7 @@ func TestCardFactory_ValidateCardType(t *testing.T) {
 	factory := NewCardFactory()
 
 	// Test valid card types
-	validTypes := []string{"verb", "adjective", "normal", "grammar"}
+	validTypes := []string{"verb", "adjective", "normal", "grammar", "kanji", "cloze"}
 	for _, cardType := range validTypes {
 		err := factory.ValidateCardType(cardType)
 		if err != nil {
@@ -324,3 +325,99 @@ func TestCardFactory_CreateCardWithValidData(t *testing.T) {
 		})
 	}
 }
+
+func TestCardFactory_DecodeCard(t *testing.T) {
+	factory := NewCardFactory()
+
+	// Incomplete data is accepted since decoding does not validate
+	card, err := factory.DecodeCard("grammar", map[string]interface{}{
+		"文法要點": "〜ても",
+		"意義說明": "即使…也…",
+	})
+	if err != nil {
+		t.Fatalf("DecodeCard(grammar) returned error: %v", err)
+	}
+
+	grammarCard, ok := card.(*GrammarCard)
+	if !ok {
+		t.Fatalf("DecodeCard(grammar) returned %T, expected *GrammarCard", card)
+	}
+	if grammarCard.GrammarPoint != "〜ても" || grammarCard.Meaning != "即使…也…" {
+		t.Errorf("DecodeCard(grammar) = %+v", grammarCard)
+	}
+
+	for _, cardType := range factory.GetSupportedCardTypes() {
+		card, err := factory.DecodeCard(cardType, map[string]interface{}{})
+		if err != nil {
+			t.Errorf("DecodeCard(%s) returned error: %v", cardType, err)
+			continue
+		}
+		if card.GetCardType() != cardType {
+			t.Errorf("DecodeCard(%s) returned card of type '%s'", cardType, card.GetCardType())
+		}
+	}
+
+	if _, err := factory.DecodeCard("invalid", nil); err == nil {
+		t.Error("DecodeCard(invalid) did not return error")
+	}
+}
+
+func TestCardFactory_CreateCardReportsAllErrors(t *testing.T) {
+	factory := NewCardFactory()
+
+	_, err := factory.CreateCard("verb", map[string]interface{}{
+		"核心單字": "飲む",
+	})
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("CreateCard(verb) error = %v, expected ValidationErrors", err)
+	}
+	if len(errs) != 4 {
+		t.Errorf("CreateCard(verb) returned %d errors, expected 4: %v", len(errs), errs)
+	}
+	if len(errs.Warnings()) != 0 {
+		t.Errorf("CreateCard(verb) error should not include warnings, got %v", errs.Warnings())
+	}
+}
+
+func TestCardFactory_FieldNames(t *testing.T) {
+	factory := NewCardFactory()
+
+	expected := map[string][]string{
+		"verb":      {"核心單字", "詞性分類", "核心意義", "發音", "重音", "常用變化", "情境例句", "例句翻譯", "圖片提示", "音檔"},
+		"adjective": {"核心單字", "詞性分類", "核心意義", "發音", "重音", "主要變化", "情境例句", "例句翻譯", "相關詞彙", "音檔"},
+		"normal":    {"核心單字", "詞性分類", "核心意義", "發音", "重音", "使用方式", "情境例句", "例句翻譯", "同義詞", "反義詞", "圖片提示", "音檔"},
+		"grammar":   {"文法要點", "結構形式", "意義說明", "使用時機", "例句示範", "例句翻譯", "情境課題", "解答範例", "難度等級", "相關文法", "常見錯誤", "記憶技巧"},
+		"kanji":     {"漢字", "核心意義", "音讀", "訓讀", "部首", "筆畫數", "構成要素", "例詞", "JLPT", "筆順"},
+		"cloze":     {"克漏字", "例句翻譯", "出處", "備註"},
+	}
+
+	for cardType, want := range expected {
+		fields, err := factory.FieldNames(cardType)
+		if err != nil {
+			t.Fatalf("FieldNames(%s) returned error: %v", cardType, err)
+		}
+		if len(fields) != len(want) {
+			t.Fatalf("FieldNames(%s) = %v, expected %v", cardType, fields, want)
+		}
+		for i := range want {
+			if fields[i] != want[i] {
+				t.Errorf("FieldNames(%s)[%d] = %s, expected %s", cardType, i, fields[i], want[i])
+			}
+		}
+
+		// Every field name round-trips through ToMap
+		card, _ := factory.DecodeCard(cardType, map[string]interface{}{})
+		cardMap := card.(CardData).ToMap()
+		for _, field := range fields {
+			if _, ok := cardMap[field]; !ok {
+				t.Errorf("ToMap() of %s card is missing field '%s'", cardType, field)
+			}
+		}
+	}
+
+	if _, err := factory.FieldNames("invalid"); err == nil {
+		t.Error("FieldNames(invalid) did not return error")
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldNames 依結構定義順序取得卡片的欄位名稱 (json 標籤)
+// 這是 Anki 模型欄位的唯一來源，第一個欄位即為 Anki 判斷重複的欄位
+func FieldNames(card interface{}) []string {
+	t := reflect.TypeOf(card)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -24,23 +24,24 @@ func (g *GrammarCard) GetCardType() string {
 }
 
 // Validate 驗證卡片資料
-func (g *GrammarCard) Validate() error {
+func (g *GrammarCard) Validate() ValidationErrors {
+	var errs ValidationErrors
 	if g.GrammarPoint == "" {
-		return NewValidationError("文法要點", "不能為空")
+		errs = append(errs, NewValidationError("文法要點", "不能為空"))
 	}
 	if g.Structure == "" {
-		return NewValidationError("結構形式", "不能為空")
+		errs = append(errs, NewValidationError("結構形式", "不能為空"))
 	}
 	if g.Meaning == "" {
-		return NewValidationError("意義說明", "不能為空")
+		errs = append(errs, NewValidationError("意義說明", "不能為空"))
 	}
 	if g.Examples == "" {
-		return NewValidationError("例句示範", "不能為空")
+		errs = append(errs, NewValidationError("例句示範", "不能為空"))
 	}
 	if g.Translation == "" {
-		return NewValidationError("例句翻譯", "不能為空")
+		errs = append(errs, NewValidationError("例句翻譯", "不能為空"))
 	}
-	return nil
+	return errs
 }
 
 // ToMap 轉換為 map 格式
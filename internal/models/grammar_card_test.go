@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -96,14 +97,14 @@ func TestGrammarCard_Validate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.card.Validate()
+			err := tt.card.Validate().Err()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GrammarCard.Validate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil {
-				validationErr, ok := err.(*ValidationError)
-				if !ok {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
 					t.Errorf("Expected ValidationError, got %T", err)
 					return
 				}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"anki-japanese-cli/internal/japanese"
+)
+
+// KanjiCard 漢字卡片類型
+type KanjiCard struct {
+	Kanji       string `json:"漢字"`
+	CoreMeaning string `json:"核心意義"`
+	OnReading   string `json:"音讀"`
+	KunReading  string `json:"訓讀"`
+	Radical     string `json:"部首"`
+	StrokeCount string `json:"筆畫數"`
+	Components  string `json:"構成要素"`
+	Examples    string `json:"例詞"`
+	JLPTLevel   string `json:"JLPT,omitempty"`
+	StrokeOrder string `json:"筆順,omitempty"`
+}
+
+// GetCardType 返回卡片類型
+func (k *KanjiCard) GetCardType() string {
+	return "kanji"
+}
+
+// Validate 驗證卡片資料
+func (k *KanjiCard) Validate() ValidationErrors {
+	var errs ValidationErrors
+	if k.Kanji == "" {
+		errs = append(errs, NewValidationError("漢字", "不能為空"))
+	} else if !isSingleKanji(k.Kanji) {
+		errs = append(errs, NewValidationError("漢字", "必須是單一漢字"))
+	}
+	if k.CoreMeaning == "" {
+		errs = append(errs, NewValidationError("核心意義", "不能為空"))
+	}
+	if k.OnReading == "" && k.KunReading == "" {
+		errs = append(errs, NewValidationError("音讀", "音讀與訓讀至少需填寫一項"))
+	}
+	if k.OnReading != "" && !isKanaReading(k.OnReading) {
+		errs = append(errs, NewValidationError("音讀", "只能包含假名"))
+	}
+	if k.KunReading != "" && !isKanaReading(k.KunReading) {
+		errs = append(errs, NewValidationError("訓讀", "只能包含假名"))
+	}
+	if k.StrokeCount != "" {
+		if count, err := strconv.Atoi(k.StrokeCount); err != nil || count <= 0 {
+			errs = append(errs, NewValidationError("筆畫數", "必須是正整數"))
+		}
+	}
+	if k.JLPTLevel != "" && !isJLPTLevel(k.JLPTLevel) {
+		errs = append(errs, NewValidationError("JLPT", "必須是 N1 到 N5"))
+	}
+	if k.Examples == "" {
+		errs = append(errs, NewValidationWarning("例詞", "建議填寫"))
+	}
+	return errs
+}
+
+// ToMap 轉換為 map 格式
+func (k *KanjiCard) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+		"漢字":   k.Kanji,
+		"核心意義": k.CoreMeaning,
+		"音讀":   k.OnReading,
+		"訓讀":   k.KunReading,
+		"部首":   k.Radical,
+		"筆畫數":  k.StrokeCount,
+		"構成要素": k.Components,
+		"例詞":   k.Examples,
+		"JLPT": k.JLPTLevel,
+		"筆順":   k.StrokeOrder,
+	}
+}
+
+// FromMap 從 map 載入資料 (筆畫數可為數字或字串)
+func (k *KanjiCard) FromMap(data map[string]interface{}) error {
+	if count, ok := data["筆畫數"].(float64); ok {
+		normalized := make(map[string]interface{}, len(data))
+		for key, value := range data {
+			normalized[key] = value
+		}
+		normalized["筆畫數"] = strconv.FormatFloat(count, 'f', -1, 64)
+		data = normalized
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, k)
+}
+
+// isSingleKanji 檢查字串是否為單一 CJK 表意文字
+func isSingleKanji(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	return size == len(s) && japanese.IsKanji(r)
+}
+
+// isKanaReading 檢查讀音是否只包含假名；
+// 允許長音符、送假名分隔 (.)、接辭標記 (-) 與多個讀音之間的分隔符號
+func isKanaReading(s string) bool {
+	hasKana := false
+	for _, r := range s {
+		switch {
+		case japanese.IsKana(r):
+			hasKana = true
+		case strings.ContainsRune("、，,・ 　.-()（）", r):
+		default:
+			return false
+		}
+	}
+	return hasKana
+}
+
+// isJLPTLevel 檢查是否為 N1 到 N5
+func isJLPTLevel(level string) bool {
+	level = strings.ToUpper(strings.TrimSpace(level))
+	return len(level) == 2 && level[0] == 'N' && level[1] >= '1' && level[1] <= '5'
+}
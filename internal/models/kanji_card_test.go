@@ -0,0 +1,106 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKanjiCard_GetCardType(t *testing.T) {
+	card := &KanjiCard{}
+	if card.GetCardType() != "kanji" {
+		t.Errorf("Expected card type to be 'kanji', got '%s'", card.GetCardType())
+	}
+}
+
+func TestKanjiCard_Validate(t *testing.T) {
+	valid := KanjiCard{
+		Kanji:       "語",
+		CoreMeaning: "語言、說話",
+		OnReading:   "ゴ",
+		KunReading:  "かた.る、かた.らう",
+		Radical:     "言",
+		StrokeCount: "14",
+		Components:  "言、吾",
+		Examples:    "日本語（にほんご）",
+		JLPTLevel:   "N5",
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(card *KanjiCard)
+		wantErr  bool
+		errField string
+	}{
+		{"Valid card", func(card *KanjiCard) {}, false, ""},
+		{"Only kun reading", func(card *KanjiCard) { card.OnReading = "" }, false, ""},
+		{"Missing kanji", func(card *KanjiCard) { card.Kanji = "" }, true, "漢字"},
+		{"Several characters", func(card *KanjiCard) { card.Kanji = "日本" }, true, "漢字"},
+		{"Kana instead of kanji", func(card *KanjiCard) { card.Kanji = "ご" }, true, "漢字"},
+		{"Missing meaning", func(card *KanjiCard) { card.CoreMeaning = "" }, true, "核心意義"},
+		{"No readings", func(card *KanjiCard) { card.OnReading, card.KunReading = "", "" }, true, "音讀"},
+		{"Romaji on reading", func(card *KanjiCard) { card.OnReading = "go" }, true, "音讀"},
+		{"Kanji in kun reading", func(card *KanjiCard) { card.KunReading = "語る" }, true, "訓讀"},
+		{"Invalid stroke count", func(card *KanjiCard) { card.StrokeCount = "十四" }, true, "筆畫數"},
+		{"Invalid JLPT level", func(card *KanjiCard) { card.JLPTLevel = "N6" }, true, "JLPT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := valid
+			tt.modify(&card)
+
+			err := card.Validate().Err()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error is not a ValidationError: %v", err)
+			}
+			if validationErr.Field != tt.errField {
+				t.Errorf("Validate() error field = %s, expected %s", validationErr.Field, tt.errField)
+			}
+		})
+	}
+}
+
+func TestKanjiCard_ValidateWarningsOnly(t *testing.T) {
+	card := KanjiCard{Kanji: "語", CoreMeaning: "語言", OnReading: "ゴ"}
+
+	errs := card.Validate()
+	if errs.HasErrors() {
+		t.Fatalf("Validate() returned errors: %v", errs.Errors())
+	}
+	if warnings := errs.Warnings(); len(warnings) != 1 || warnings[0].Field != "例詞" {
+		t.Errorf("Validate() warnings = %v, expected a warning for 例詞", warnings)
+	}
+}
+
+func TestKanjiCard_FromMap(t *testing.T) {
+	card := &KanjiCard{}
+	err := card.FromMap(map[string]interface{}{
+		"漢字":   "語",
+		"核心意義": "語言",
+		"音讀":   "ゴ",
+		"筆畫數":  float64(14),
+		"JLPT": "N5",
+	})
+	if err != nil {
+		t.Fatalf("FromMap() returned error: %v", err)
+	}
+	if card.StrokeCount != "14" {
+		t.Errorf("FromMap() StrokeCount = %q, expected \"14\"", card.StrokeCount)
+	}
+	if card.Kanji != "語" || card.JLPTLevel != "N5" {
+		t.Errorf("FromMap() = %+v", card)
+	}
+
+	data := card.ToMap()
+	if data["筆畫數"] != "14" || data["音讀"] != "ゴ" {
+		t.Errorf("ToMap() = %v", data)
+	}
+}
@@ -15,6 +15,7 @@ type NormalWordCard struct {
 	Synonyms        string `json:"同義詞,omitempty"`
 	Antonyms        string `json:"反義詞,omitempty"`
 	ImageHint       string `json:"圖片提示,omitempty"`
+	Audio           string `json:"音檔,omitempty"`
 }
 
 // GetCardType 返回卡片類型
@@ -23,23 +24,30 @@ func (n *NormalWordCard) GetCardType() string {
 }
 
 // Validate 驗證卡片資料
-func (n *NormalWordCard) Validate() error {
+func (n *NormalWordCard) Validate() ValidationErrors {
+	var errs ValidationErrors
 	if n.CoreWord == "" {
-		return NewValidationError("核心單字", "不能為空")
+		errs = append(errs, NewValidationError("核心單字", "不能為空"))
 	}
 	if n.CoreMeaning == "" {
-		return NewValidationError("核心意義", "不能為空")
+		errs = append(errs, NewValidationError("核心意義", "不能為空"))
 	}
-	if n.Pronunciation == "" {
-		return NewValidationError("發音", "不能為空")
+	if err := validateReading(n.Pronunciation); err != nil {
+		errs = append(errs, err)
 	}
 	if n.ContextSentence == "" {
-		return NewValidationError("情境例句", "不能為空")
+		errs = append(errs, NewValidationError("情境例句", "不能為空"))
 	}
 	if n.Translation == "" {
-		return NewValidationError("例句翻譯", "不能為空")
+		errs = append(errs, NewValidationError("例句翻譯", "不能為空"))
 	}
-	return nil
+	if err := validateAccent(n.Accent, n.Pronunciation); err != nil {
+		errs = append(errs, err)
+	}
+	if n.ImageHint == "" {
+		errs = append(errs, NewValidationWarning("圖片提示", "建議填寫"))
+	}
+	return errs
 }
 
 // ToMap 轉換為 map 格式
@@ -56,6 +64,7 @@ func (n *NormalWordCard) ToMap() map[string]interface{} {
 		"同義詞":  n.Synonyms,
 		"反義詞":  n.Antonyms,
 		"圖片提示": n.ImageHint,
+		"音檔":   n.Audio,
 	}
 }
 
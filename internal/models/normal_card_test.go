@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -95,14 +96,14 @@ func TestNormalWordCard_Validate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.card.Validate()
+			err := tt.card.Validate().Err()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NormalWordCard.Validate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil {
-				validationErr, ok := err.(*ValidationError)
-				if !ok {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
 					t.Errorf("Expected ValidationError, got %T", err)
 					return
 				}
@@ -247,3 +248,42 @@ func TestNormalWordCard_JSONSerialization(t *testing.T) {
 		t.Errorf("JSON serialization/deserialization failed. Original: %v, Deserialized: %v", original, deserialized)
 	}
 }
+
+func TestNormalWordCard_ValidateAccent(t *testing.T) {
+	tests := []struct {
+		name        string
+		accent      string
+		wantErr     bool
+		wantWarning bool
+	}{
+		{"Number", "2", false, false},
+		{"Circled number", "⓪", false, false},
+		{"Several accents", "0/3", false, false},
+		{"Beyond mora count", "5", true, false},
+		{"Pattern name", "平板", false, false},
+		{"Pattern name with 型", "尾高型", false, false},
+		{"Ambiguous pattern name", "中高", false, true},
+		{"Free-form text", "高く始まる", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := &NormalWordCard{
+				CoreWord:        "学校",
+				CoreMeaning:     "學校",
+				Pronunciation:   "がっこう",
+				Accent:          tt.accent,
+				ContextSentence: "学校に行く",
+				Translation:     "去學校",
+				ImageHint:       "school.jpg",
+			}
+			errs := card.Validate()
+			if err := errs.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if warnings := errs.Warnings(); (len(warnings) > 0) != tt.wantWarning {
+				t.Errorf("Validate() warnings = %v, wantWarning %v", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"anki-japanese-cli/internal/japanese"
+)
+
+// validateReading 檢查發音不為空且只包含假名；
+// 羅馬拼音應先以 japanese.NormalizeReading 轉換為平假名 (add 與 update 會自動轉換)
+func validateReading(reading string) *ValidationError {
+	if reading == "" {
+		return NewValidationError("發音", "不能為空")
+	}
+	script := japanese.Detect(reading)
+	switch {
+	case script.Has(japanese.Kanji):
+		return NewValidationError("發音", "不能包含漢字，請以假名填寫")
+	case script.Has(japanese.Romaji):
+		return NewValidationError("發音", "不能包含英文字母，請以假名或可轉換的羅馬拼音填寫")
+	}
+	return nil
+}
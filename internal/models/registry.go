@@ -0,0 +1,344 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CardTypeDefinition 卡片類型定義 (內建類型或由 YAML 檔案載入的自訂類型)
+//
+// YAML 範例:
+//
+//	name: counter
+//	model: Japanese Counter
+//	deck: 日文助數詞
+//	fields: [助數詞, 發音, 計算對象, 例句]
+//	required: [助數詞, 發音]
+//	front: "{{助數詞}}"
+//	back: "{{FrontSide}}<hr id=answer>{{發音}}<br>{{計算對象}}"
+//	css: ".card { font-size: 24px; }"
+type CardTypeDefinition struct {
+	Name      string   `yaml:"name"`     // 卡片類型名稱 (指令中使用，例如 counter)
+	ModelName string   `yaml:"model"`    // Anki 模型名稱
+	Deck      string   `yaml:"deck"`     // 預設牌組
+	Fields    []string `yaml:"fields"`   // 模型欄位 (依順序)
+	Required  []string `yaml:"required"` // 必填欄位
+	KeyField  string   `yaml:"key"`      // 關鍵欄位 (用於重複檢查與搜尋)，預設為第一個欄位
+	Front     string   `yaml:"front"`    // Anki 正面模板 (mustache 語法)
+	Back      string   `yaml:"back"`     // Anki 背面模板 (mustache 語法)
+	CSS       string   `yaml:"css"`      // 卡片樣式
+	IsCloze   bool     `yaml:"isCloze"`  // 是否建立為 Anki 的 Cloze 模型
+
+	// Templates 額外的卡片模板 (自訂類型)，每個模板會讓同一筆記多產生一張卡片
+	Templates []TemplateDefinition `yaml:"templates"`
+	// Directions 可額外產生的卡片方向 (內建類型，例如 production、reading、listening)
+	Directions []string `yaml:"-"`
+
+	// Builtin 為 true 表示內建類型 (由 Go 結構驗證，模板來自嵌入的 HTML)
+	Builtin bool `yaml:"-"`
+	// Label 錯誤訊息中的卡片名稱 (例如 動詞)，未指定時使用 Name
+	Label string `yaml:"-"`
+
+	// newCard 建立內建類型的空白卡片 (對應的 Go 結構)；自訂類型為 nil
+	newCard func() Card
+}
+
+// TemplateDefinition 自訂卡片類型的額外卡片模板
+type TemplateDefinition struct {
+	Name  string `yaml:"name"`
+	Front string `yaml:"front"` // Anki 正面模板 (mustache 語法)
+	Back  string `yaml:"back"`  // Anki 背面模板 (mustache 語法)
+}
+
+// wordDirections 單字類卡片 (有核心單字、核心意義、發音與音檔欄位) 可產生的卡片方向
+var wordDirections = []string{"production", "reading", "listening"}
+
+// cardTypeNamePattern 卡片類型名稱的格式
+var cardTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// Validate 檢查卡片類型定義是否完整
+func (d *CardTypeDefinition) Validate() error {
+	if !cardTypeNamePattern.MatchString(d.Name) {
+		return fmt.Errorf("卡片類型名稱 '%s' 無效 (只能使用小寫英文字母、數字、- 與 _)", d.Name)
+	}
+	if d.ModelName == "" {
+		return fmt.Errorf("卡片類型 '%s' 缺少 model", d.Name)
+	}
+	if d.Deck == "" {
+		return fmt.Errorf("卡片類型 '%s' 缺少 deck", d.Name)
+	}
+	if len(d.Fields) == 0 {
+		return fmt.Errorf("卡片類型 '%s' 缺少 fields", d.Name)
+	}
+
+	fields := make(map[string]bool, len(d.Fields))
+	for _, field := range d.Fields {
+		if strings.TrimSpace(field) == "" {
+			return fmt.Errorf("卡片類型 '%s' 有空白的欄位名稱", d.Name)
+		}
+		if fields[field] {
+			return fmt.Errorf("卡片類型 '%s' 的欄位 '%s' 重複", d.Name, field)
+		}
+		fields[field] = true
+	}
+	for _, field := range d.Required {
+		if !fields[field] {
+			return fmt.Errorf("卡片類型 '%s' 的必填欄位 '%s' 不在 fields 中", d.Name, field)
+		}
+	}
+	if d.KeyField != "" && !fields[d.KeyField] {
+		return fmt.Errorf("卡片類型 '%s' 的關鍵欄位 '%s' 不在 fields 中", d.Name, d.KeyField)
+	}
+
+	if !d.Builtin && (d.Front == "" || d.Back == "") {
+		return fmt.Errorf("卡片類型 '%s' 缺少 front 或 back 模板", d.Name)
+	}
+
+	templates := map[string]bool{d.ModelName: true}
+	for _, tmpl := range d.Templates {
+		if tmpl.Name == "" || tmpl.Front == "" || tmpl.Back == "" {
+			return fmt.Errorf("卡片類型 '%s' 的額外模板必須有 name、front 與 back", d.Name)
+		}
+		if templates[tmpl.Name] {
+			return fmt.Errorf("卡片類型 '%s' 的模板名稱 '%s' 重複", d.Name, tmpl.Name)
+		}
+		templates[tmpl.Name] = true
+	}
+	if d.IsCloze && len(d.Templates) > 0 {
+		return fmt.Errorf("卡片類型 '%s' 為 Cloze 模型，不能有額外模板", d.Name)
+	}
+	return nil
+}
+
+// Key 回傳關鍵欄位名稱
+func (d *CardTypeDefinition) Key() string {
+	if d.KeyField != "" {
+		return d.KeyField
+	}
+	return d.Fields[0]
+}
+
+// NewCard 建立這個類型的空白卡片：內建類型為對應的 Go 結構，自訂類型為 CustomCard
+func (d *CardTypeDefinition) NewCard() Card {
+	if d.newCard != nil {
+		return d.newCard()
+	}
+	return NewCustomCard(d)
+}
+
+// label 回傳錯誤訊息中的卡片名稱；英文名稱前後加上空格 (建立 counter 卡片失敗)
+func (d *CardTypeDefinition) label() string {
+	if d.Label != "" {
+		return d.Label
+	}
+	return " " + d.Name + " "
+}
+
+// SupportsDirection 回傳卡片類型是否可額外產生指定方向的卡片
+func (d *CardTypeDefinition) SupportsDirection(direction string) bool {
+	for _, supported := range d.Directions {
+		if supported == direction {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseCardTypeDefinition 解析 YAML 格式的卡片類型定義
+func ParseCardTypeDefinition(data []byte) (*CardTypeDefinition, error) {
+	var def CardTypeDefinition
+	if err := yaml.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("YAML 解析失敗: %w", err)
+	}
+	def.Name = strings.ToLower(strings.TrimSpace(def.Name))
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+	return &def, nil
+}
+
+// Registry 卡片類型登錄表
+type Registry struct {
+	types map[string]*CardTypeDefinition
+	order []string
+}
+
+// NewRegistry 建立只包含內建卡片類型的登錄表
+func NewRegistry() *Registry {
+	r := &Registry{types: make(map[string]*CardTypeDefinition)}
+	for _, def := range builtinCardTypes() {
+		if err := r.Register(def); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// builtinCardTypes 內建的卡片類型 (欄位由卡片結構的 json 標籤產生)
+func builtinCardTypes() []*CardTypeDefinition {
+	return []*CardTypeDefinition{
+		{
+			Name:       "verb",
+			ModelName:  "Japanese Verb",
+			Deck:       "日文動詞",
+			Fields:     FieldNames(VerbCard{}),
+			KeyField:   "核心單字",
+			Directions: wordDirections,
+			Builtin:    true,
+			Label:      "動詞",
+			newCard:    func() Card { return &VerbCard{} },
+		},
+		{
+			Name:       "adjective",
+			ModelName:  "Japanese Adjective",
+			Deck:       "日文形容詞",
+			Fields:     FieldNames(AdjectiveCard{}),
+			KeyField:   "核心單字",
+			Directions: wordDirections,
+			Builtin:    true,
+			Label:      "形容詞",
+			newCard:    func() Card { return &AdjectiveCard{} },
+		},
+		{
+			Name:       "normal",
+			ModelName:  "Japanese Normal Word",
+			Deck:       "日文單字",
+			Fields:     FieldNames(NormalWordCard{}),
+			KeyField:   "核心單字",
+			Directions: wordDirections,
+			Builtin:    true,
+			Label:      "一般單字",
+			newCard:    func() Card { return &NormalWordCard{} },
+		},
+		{
+			Name:      "grammar",
+			ModelName: "Japanese Grammar",
+			Deck:      "日文文法",
+			Fields:    FieldNames(GrammarCard{}),
+			KeyField:  "文法要點",
+			Builtin:   true,
+			Label:     "文法",
+			newCard:   func() Card { return &GrammarCard{} },
+		},
+		{
+			Name:      "kanji",
+			ModelName: "Japanese Kanji",
+			Deck:      "日文漢字",
+			Fields:    FieldNames(KanjiCard{}),
+			KeyField:  "漢字",
+			Builtin:   true,
+			Label:     "漢字",
+			newCard:   func() Card { return &KanjiCard{} },
+		},
+		{
+			Name:      "cloze",
+			ModelName: "Japanese Cloze",
+			Deck:      "日文克漏字",
+			Fields:    FieldNames(ClozeCard{}),
+			KeyField:  "克漏字",
+			IsCloze:   true,
+			Builtin:   true,
+			Label:     "克漏字",
+			newCard:   func() Card { return &ClozeCard{} },
+		},
+	}
+}
+
+// defaultRegistry 預設的卡片類型登錄表
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry 回傳 NewCardFactory 使用的預設登錄表
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register 登錄卡片類型；名稱或 Anki 模型名稱與既有類型相同時回傳錯誤
+func (r *Registry) Register(def *CardTypeDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+	if _, exists := r.types[def.Name]; exists {
+		return fmt.Errorf("卡片類型 '%s' 已存在", def.Name)
+	}
+	for _, name := range r.order {
+		if r.types[name].ModelName == def.ModelName {
+			return fmt.Errorf("卡片類型 '%s' 的模型 '%s' 已被 '%s' 使用", def.Name, def.ModelName, name)
+		}
+	}
+
+	r.types[def.Name] = def
+	r.order = append(r.order, def.Name)
+	return nil
+}
+
+// Get 取得卡片類型定義
+func (r *Registry) Get(cardType string) (*CardTypeDefinition, bool) {
+	def, ok := r.types[cardType]
+	return def, ok
+}
+
+// ForModel 根據 Anki 模型名稱找出卡片類型定義
+func (r *Registry) ForModel(modelName string) (*CardTypeDefinition, bool) {
+	for _, name := range r.order {
+		if def := r.types[name]; def.ModelName == modelName {
+			return def, true
+		}
+	}
+	return nil, false
+}
+
+// Names 回傳所有卡片類型名稱 (內建類型在前，自訂類型依載入順序)
+func (r *Registry) Names() []string {
+	return append([]string(nil), r.order...)
+}
+
+// LoadFile 從 YAML 檔案載入並登錄卡片類型
+func (r *Registry) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("無法讀取卡片類型檔案: %w", err)
+	}
+
+	def, err := ParseCardTypeDefinition(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	if err := r.Register(def); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// LoadDir 載入目錄中所有的 .yaml / .yml 卡片類型定義 (依檔名順序)；目錄不存在時不做任何事
+func (r *Registry) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("無法讀取卡片類型目錄: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if err := r.LoadFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
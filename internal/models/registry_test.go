@@ -0,0 +1,218 @@
+package models
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const counterYAML = `name: counter
+model: Japanese Counter
+deck: 日文助數詞
+fields: [助數詞, 發音, 計算對象, 例句]
+required: [助數詞, 發音]
+front: "{{助數詞}}"
+back: "{{FrontSide}}<hr id=answer>{{發音}}"
+css: ".card { font-size: 24px; }"
+`
+
+func TestParseCardTypeDefinition(t *testing.T) {
+	def, err := ParseCardTypeDefinition([]byte(counterYAML))
+	if err != nil {
+		t.Fatalf("ParseCardTypeDefinition() error = %v", err)
+	}
+	if def.Name != "counter" || def.ModelName != "Japanese Counter" || def.Deck != "日文助數詞" {
+		t.Errorf("ParseCardTypeDefinition() = %+v", def)
+	}
+	if len(def.Fields) != 4 || def.Key() != "助數詞" {
+		t.Errorf("Fields = %v, Key() = %s", def.Fields, def.Key())
+	}
+	if def.Builtin {
+		t.Error("YAML card types must not be builtin")
+	}
+}
+
+func TestParseCardTypeDefinition_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		yaml     string
+		contains string
+	}{
+		{"bad name", strings.Replace(counterYAML, "name: counter", "name: 助數詞", 1), "名稱"},
+		{"missing model", strings.Replace(counterYAML, "model: Japanese Counter", "", 1), "model"},
+		{"required not a field", strings.Replace(counterYAML, "required: [助數詞, 發音]", "required: [讀音]", 1), "讀音"},
+		{"duplicate field", strings.Replace(counterYAML, "例句]", "發音]", 1), "重複"},
+		{"missing template", strings.Replace(counterYAML, `front: "{{助數詞}}"`, "", 1), "front"},
+		{"bad yaml", "name: [", "YAML"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCardTypeDefinition([]byte(tt.yaml))
+			if err == nil {
+				t.Fatal("ParseCardTypeDefinition() expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("error = %v, expected it to mention %q", err, tt.contains)
+			}
+		})
+	}
+}
+
+func TestRegistry_LoadDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "counter.yaml"), []byte(counterYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewRegistry()
+	if err := registry.LoadDir(dir); err != nil {
+		t.Fatalf("LoadDir() error = %v", err)
+	}
+
+	names := registry.Names()
+	builtin := len(NewRegistry().Names())
+	if len(names) != builtin+1 || names[builtin] != "counter" {
+		t.Errorf("Names() = %v, expected the builtin types followed by counter", names)
+	}
+	if def, ok := registry.ForModel("Japanese Counter"); !ok || def.Name != "counter" {
+		t.Errorf("ForModel(Japanese Counter) = %v, %v", def, ok)
+	}
+
+	// Loading the same type twice is rejected
+	if err := registry.LoadDir(dir); err == nil {
+		t.Error("LoadDir() expected an error for an already registered type")
+	}
+
+	// A missing directory is not an error
+	if err := NewRegistry().LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("LoadDir(missing) error = %v", err)
+	}
+}
+
+func TestRegistry_RegisterConflicts(t *testing.T) {
+	registry := NewRegistry()
+
+	def, err := ParseCardTypeDefinition([]byte(strings.Replace(counterYAML, "name: counter", "name: verb", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(def); err == nil {
+		t.Error("Register() expected an error when overriding a builtin type")
+	}
+
+	def, err = ParseCardTypeDefinition([]byte(strings.Replace(counterYAML, "Japanese Counter", "Japanese Verb", 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(def); err == nil {
+		t.Error("Register() expected an error when reusing a model name")
+	}
+}
+
+func TestCardFactory_CustomCardType(t *testing.T) {
+	registry := NewRegistry()
+	def, err := ParseCardTypeDefinition([]byte(counterYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register(def); err != nil {
+		t.Fatal(err)
+	}
+	factory := NewCardFactoryWithRegistry(registry)
+
+	if err := factory.ValidateCardType("counter"); err != nil {
+		t.Errorf("ValidateCardType(counter) error = %v", err)
+	}
+
+	card, err := factory.CreateCard("counter", map[string]interface{}{
+		"助數詞":  "本",
+		"發音":   "ほん",
+		"計算對象": "細長い物",
+	})
+	if err != nil {
+		t.Fatalf("CreateCard() error = %v", err)
+	}
+	if card.GetCardType() != "counter" {
+		t.Errorf("GetCardType() = %s, expected counter", card.GetCardType())
+	}
+	if data := card.(CardData).ToMap(); data["助數詞"] != "本" {
+		t.Errorf("ToMap() = %v", data)
+	}
+
+	_, err = factory.CreateCard("counter", map[string]interface{}{"計算對象": "細長い物"})
+	var validationErrs ValidationErrors
+	if !errors.As(err, &validationErrs) || len(validationErrs) != 2 {
+		t.Errorf("CreateCard() error = %v, expected 2 missing required fields", err)
+	}
+
+	fields, err := factory.FieldNames("counter")
+	if err != nil || len(fields) != 4 {
+		t.Errorf("FieldNames(counter) = %v, %v", fields, err)
+	}
+
+	// The default factory does not know about types registered elsewhere
+	if err := NewCardFactory().ValidateCardType("counter"); err == nil {
+		t.Error("default factory should not include the counter type")
+	}
+}
+
+func TestRegistry_BuiltinCardTypes(t *testing.T) {
+	for _, def := range NewRegistry().types {
+		if !def.Builtin {
+			continue
+		}
+		card := def.NewCard()
+		if _, ok := card.(*CustomCard); ok {
+			t.Errorf("NewCard() for %s returned a CustomCard, expected the built-in struct", def.Name)
+			continue
+		}
+		if card.GetCardType() != def.Name {
+			t.Errorf("NewCard() for %s returned a %s card", def.Name, card.GetCardType())
+		}
+		data := card.ToMap()
+		for _, field := range def.Fields {
+			if _, ok := data[field]; !ok {
+				t.Errorf("%s card is missing registry field %s", def.Name, field)
+			}
+		}
+	}
+
+	// Built-in types registered on another registry are created by the factory without further wiring
+	registry := &Registry{types: make(map[string]*CardTypeDefinition)}
+	if err := registry.Register(&CardTypeDefinition{
+		Name:      "verb",
+		ModelName: "Japanese Verb (test)",
+		Deck:      "test",
+		Fields:    FieldNames(VerbCard{}),
+		Builtin:   true,
+		newCard:   func() Card { return &VerbCard{} },
+	}); err != nil {
+		t.Fatal(err)
+	}
+	card, err := NewCardFactoryWithRegistry(registry).DecodeCard("verb", map[string]interface{}{"核心單字": "飲む"})
+	if err != nil {
+		t.Fatalf("DecodeCard() error = %v", err)
+	}
+	if verb, ok := card.(*VerbCard); !ok || verb.CoreWord != "飲む" {
+		t.Errorf("DecodeCard() = %#v, expected a VerbCard for 飲む", card)
+	}
+	if _, err := NewCardFactoryWithRegistry(registry).CreateCard("adjective", nil); err == nil {
+		t.Error("CreateCard(adjective) should fail for a type missing from the registry")
+	}
+}
+
+func TestRegistry_LoadExample(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.LoadFile(filepath.Join("..", "..", "examples", "counter.yaml")); err != nil {
+		t.Fatalf("LoadFile(examples/counter.yaml) error = %v", err)
+	}
+	if _, ok := registry.Get("counter"); !ok {
+		t.Error("example counter type was not registered")
+	}
+}
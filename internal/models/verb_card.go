@@ -1,6 +1,11 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+
+	"anki-japanese-cli/internal/conjugation"
+)
 
 // VerbCard 動詞卡片類型
 type VerbCard struct {
@@ -13,6 +18,7 @@ type VerbCard struct {
 	ContextSentence string `json:"情境例句"`
 	Translation     string `json:"例句翻譯"`
 	ImageHint       string `json:"圖片提示,omitempty"`
+	Audio           string `json:"音檔,omitempty"`
 }
 
 // GetCardType 返回卡片類型
@@ -21,23 +27,80 @@ func (v *VerbCard) GetCardType() string {
 }
 
 // Validate 驗證卡片資料
-func (v *VerbCard) Validate() error {
+func (v *VerbCard) Validate() ValidationErrors {
+	var errs ValidationErrors
 	if v.CoreWord == "" {
-		return NewValidationError("核心單字", "不能為空")
+		errs = append(errs, NewValidationError("核心單字", "不能為空"))
 	}
 	if v.CoreMeaning == "" {
-		return NewValidationError("核心意義", "不能為空")
+		errs = append(errs, NewValidationError("核心意義", "不能為空"))
 	}
-	if v.Pronunciation == "" {
-		return NewValidationError("發音", "不能為空")
+	if err := validateReading(v.Pronunciation); err != nil {
+		errs = append(errs, err)
 	}
 	if v.ContextSentence == "" {
-		return NewValidationError("情境例句", "不能為空")
+		errs = append(errs, NewValidationError("情境例句", "不能為空"))
 	}
 	if v.Translation == "" {
-		return NewValidationError("例句翻譯", "不能為空")
+		errs = append(errs, NewValidationError("例句翻譯", "不能為空"))
+	}
+	if err := validateAccent(v.Accent, v.Pronunciation); err != nil {
+		errs = append(errs, err)
+	}
+	if v.ImageHint == "" {
+		errs = append(errs, NewValidationWarning("圖片提示", "建議填寫"))
+	}
+	for _, mismatch := range v.ConjugationMismatches() {
+		errs = append(errs, NewValidationWarning("常用變化",
+			fmt.Sprintf("%s應為「%s」(目前為「%s」)", mismatch.Name, mismatch.Expected, mismatch.Got)))
+	}
+	return errs
+}
+
+// ExpectedConjugations 由核心單字與詞性分類產生動詞的活用形；
+// 第二個回傳值為發音的活用形 (用於比對以假名書寫的常用變化，發音為空時為 nil)
+func (v *VerbCard) ExpectedConjugations() (conjugation.Forms, conjugation.Forms, error) {
+	class, err := conjugation.ClassifyVerb(v.CoreWord, v.WordType)
+	if err != nil {
+		return nil, nil, err
+	}
+	forms, err := conjugation.ConjugateVerb(v.CoreWord, class)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var kana conjugation.Forms
+	if v.Pronunciation != "" && v.Pronunciation != v.CoreWord {
+		kana, _ = conjugation.ConjugateVerb(v.Pronunciation, class)
+	}
+	return forms, kana, nil
+}
+
+// ConjugationMismatches 比對常用變化與產生的活用形；
+// 常用變化為空或無法判斷動詞種類時不比對
+func (v *VerbCard) ConjugationMismatches() []conjugation.Mismatch {
+	if v.Conjugations == "" {
+		return nil
+	}
+	forms, kana, err := v.ExpectedConjugations()
+	if err != nil {
+		return nil
+	}
+	return conjugation.Verify(v.Conjugations, forms, kana)
+}
+
+// FillConjugations 常用變化為空時填入產生的活用形；fix 為 true 時也取代不一致的常用變化。
+// 回傳常用變化是否被修改
+func (v *VerbCard) FillConjugations(fix bool) (bool, error) {
+	forms, _, err := v.ExpectedConjugations()
+	if err != nil {
+		return false, err
+	}
+	if v.Conjugations != "" && !(fix && len(v.ConjugationMismatches()) > 0) {
+		return false, nil
 	}
-	return nil
+	v.Conjugations = forms.String()
+	return true, nil
 }
 
 // ToMap 轉換為 map 格式
@@ -52,6 +115,7 @@ func (v *VerbCard) ToMap() map[string]interface{} {
 		"情境例句": v.ContextSentence,
 		"例句翻譯": v.Translation,
 		"圖片提示": v.ImageHint,
+		"音檔":   v.Audio,
 	}
 }
 
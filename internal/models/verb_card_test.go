@@ -2,6 +2,8 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 )
 
@@ -92,14 +94,14 @@ func TestVerbCard_Validate(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.card.Validate()
+			err := tt.card.Validate().Err()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("VerbCard.Validate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if err != nil {
-				validationErr, ok := err.(*ValidationError)
-				if !ok {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
 					t.Errorf("Expected ValidationError, got %T", err)
 					return
 				}
@@ -111,6 +113,55 @@ func TestVerbCard_Validate(t *testing.T) {
 	}
 }
 
+func TestVerbCard_ValidateReportsAllFields(t *testing.T) {
+	card := VerbCard{CoreWord: "飲む", CoreMeaning: "喝"}
+
+	issues := card.Validate()
+	var errs ValidationErrors
+	if !errors.As(issues.Err(), &errs) {
+		t.Fatalf("Expected ValidationErrors, got %T", issues.Err())
+	}
+
+	expected := []string{"發音", "情境例句", "例句翻譯"}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+	for i, field := range expected {
+		if errs[i].Field != field {
+			t.Errorf("Expected error %d to be for '%s', got '%s'", i, field, errs[i].Field)
+		}
+	}
+
+	// Missing optional fields are reported as warnings only
+	warnings := issues.Warnings()
+	if len(warnings) != 2 || warnings[0].Field != "重音" || warnings[1].Field != "圖片提示" {
+		t.Errorf("Expected warnings for 重音 and 圖片提示, got %v", warnings)
+	}
+	for _, warning := range warnings {
+		if warning.Severity != SeverityWarning {
+			t.Errorf("Expected warning severity for '%s', got %s", warning.Field, warning.Severity)
+		}
+	}
+}
+
+func TestVerbCard_ValidateWarningsOnly(t *testing.T) {
+	card := VerbCard{
+		CoreWord:        "飲む",
+		CoreMeaning:     "喝",
+		Pronunciation:   "のむ",
+		ContextSentence: "水を飲む",
+		Translation:     "喝水",
+	}
+
+	issues := card.Validate()
+	if issues.HasErrors() || issues.Err() != nil {
+		t.Errorf("Expected no errors, got %v", issues.Errors())
+	}
+	if len(issues.Warnings()) != 2 {
+		t.Errorf("Expected 2 warnings, got %v", issues.Warnings())
+	}
+}
+
 func TestVerbCard_ToMap(t *testing.T) {
 	card := VerbCard{
 		CoreWord:        "飲む",
@@ -230,3 +281,92 @@ func TestVerbCard_JSONSerialization(t *testing.T) {
 		t.Errorf("JSON serialization/deserialization failed. Original: %v, Deserialized: %v", original, deserialized)
 	}
 }
+
+func TestVerbCard_ConjugationMismatches(t *testing.T) {
+	card := VerbCard{
+		CoreWord:      "飲む",
+		WordType:      "五段動詞",
+		Pronunciation: "のむ",
+		Conjugations:  "ます形: のみます<br>て形: 飲みて<br>ない形: 飲まない",
+	}
+
+	mismatches := card.ConjugationMismatches()
+	if len(mismatches) != 1 || mismatches[0].Expected != "飲んで" {
+		t.Fatalf("ConjugationMismatches() = %v, expected the て形 to be reported", mismatches)
+	}
+
+	var warned bool
+	for _, e := range card.Validate().Warnings() {
+		if e.Field == "常用變化" {
+			warned = true
+		}
+	}
+	if !warned {
+		t.Error("Validate() expected a 常用變化 warning")
+	}
+
+	card.WordType = "動詞"
+	if mismatches := card.ConjugationMismatches(); len(mismatches) != 0 {
+		t.Errorf("ConjugationMismatches() = %v, expected no check for an unknown verb class", mismatches)
+	}
+}
+
+func TestVerbCard_FillConjugations(t *testing.T) {
+	card := VerbCard{CoreWord: "食べる", WordType: "一段動詞"}
+	changed, err := card.FillConjugations(false)
+	if err != nil || !changed {
+		t.Fatalf("FillConjugations() = %v, %v, expected the empty field to be filled", changed, err)
+	}
+	if !strings.HasPrefix(card.Conjugations, "ます形: 食べます<br>て形: 食べて") {
+		t.Errorf("Conjugations = %q", card.Conjugations)
+	}
+
+	card.Conjugations = "て形: 食べって"
+	if changed, _ := card.FillConjugations(false); changed {
+		t.Error("FillConjugations(false) should keep existing conjugations")
+	}
+	if changed, _ := card.FillConjugations(true); !changed || strings.Contains(card.Conjugations, "食べって") {
+		t.Errorf("FillConjugations(true) should replace inconsistent conjugations, got %q", card.Conjugations)
+	}
+
+	card = VerbCard{CoreWord: "飲む"}
+	if _, err := card.FillConjugations(false); err == nil {
+		t.Error("FillConjugations() expected an error without 詞性分類")
+	}
+}
+
+func TestVerbCard_ValidateReading(t *testing.T) {
+	tests := []struct {
+		name    string
+		reading string
+		wantErr bool
+	}{
+		{"Hiragana", "たべる", false},
+		{"Katakana", "タベル", false},
+		{"Kanji", "食べる", true},
+		{"Romaji", "taberu", true},
+		{"Mixed", "たべru", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card := &VerbCard{
+				CoreWord:        "食べる",
+				WordType:        "一段動詞",
+				CoreMeaning:     "吃",
+				Pronunciation:   tt.reading,
+				ContextSentence: "ご飯を食べる",
+				Translation:     "吃飯",
+			}
+			var readingErr *ValidationError
+			for _, e := range card.Validate().Errors() {
+				if e.Field == "發音" {
+					readingErr = e
+				}
+			}
+			if (readingErr != nil) != tt.wantErr {
+				t.Errorf("Validate() 發音 error = %v, wantErr %v", readingErr, tt.wantErr)
+			}
+		})
+	}
+}
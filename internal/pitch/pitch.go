@@ -0,0 +1,241 @@
+// Package pitch 解析日文的重音標記 ("0"、"1"、"②") 並依發音的假名分類重音類型，
+// 產生預覽用的音高圖 (高音加上橫線、下降處加上標記)
+package pitch
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"anki-japanese-cli/internal/japanese"
+)
+
+// Pattern 重音類型
+type Pattern int
+
+const (
+	Heiban    Pattern = iota // 平板型：第一拍低，其後不下降
+	Atamadaka                // 頭高型：第一拍高，第二拍下降
+	Nakadaka                 // 中高型：在單字中間下降
+	Odaka                    // 尾高型：在最後一拍之後 (助詞) 下降
+)
+
+// String 回傳重音類型的名稱
+func (p Pattern) String() string {
+	switch p {
+	case Heiban:
+		return "平板型"
+	case Atamadaka:
+		return "頭高型"
+	case Nakadaka:
+		return "中高型"
+	case Odaka:
+		return "尾高型"
+	}
+	return "未知"
+}
+
+// ParsePattern 解析重音類型的名稱 (平板、頭高、中高、尾高，可加上「型」)
+func ParsePattern(name string) (Pattern, bool) {
+	switch strings.TrimSuffix(strings.TrimSpace(name), "型") {
+	case "平板":
+		return Heiban, true
+	case "頭高":
+		return Atamadaka, true
+	case "中高":
+		return Nakadaka, true
+	case "尾高":
+		return Odaka, true
+	}
+	return 0, false
+}
+
+// Position 回傳重音類型在指定拍數的下降位置；
+// 中高型只有三拍的單字能確定下降位置 (第 2 拍)，其他拍數需要以數字標記
+func (p Pattern) Position(moraCount int) (int, error) {
+	switch p {
+	case Heiban:
+		return 0, nil
+	case Atamadaka:
+		return 1, nil
+	case Odaka:
+		return moraCount, nil
+	case Nakadaka:
+		if moraCount == 3 {
+			return 2, nil
+		}
+		return 0, fmt.Errorf("%d 拍的中高型無法確定下降位置，請以數字標記", moraCount)
+	}
+	return 0, fmt.Errorf("未知的重音類型")
+}
+
+// Accent 單字的重音：Position 為音高下降前最後一個高音的拍數 (0 為平板型)
+type Accent struct {
+	Position int
+	Moras    []string
+}
+
+// Pattern 依下降位置與拍數分類重音類型
+func (a Accent) Pattern() Pattern {
+	switch {
+	case a.Position == 0:
+		return Heiban
+	case a.Position == 1:
+		return Atamadaka
+	case a.Position == len(a.Moras):
+		return Odaka
+	}
+	return Nakadaka
+}
+
+// High 檢查第 i 拍 (從 0 開始) 是否為高音；頭高型以外的第一拍都是低音
+func (a Accent) High(i int) bool {
+	switch {
+	case a.Position == 1:
+		return i == 0
+	case i == 0:
+		return false
+	case a.Position == 0:
+		return true
+	}
+	return i < a.Position
+}
+
+// String 回傳重音類型與下降位置 (中高型 [2])
+func (a Accent) String() string {
+	return fmt.Sprintf("%s [%d]", a.Pattern(), a.Position)
+}
+
+// HTML 產生音高圖：每一拍為一個 <span>，高音加上 pitch-high (橫線)，
+// 由低轉高加上 pitch-rise，下降前的最後一拍加上 pitch-drop (下降標記)
+func (a Accent) HTML() string {
+	var out strings.Builder
+	out.WriteString(`<span class="pitch-graph" title="` + html.EscapeString(a.String()) + `">`)
+	for i, mora := range a.Moras {
+		classes := []string{"pitch-mora"}
+		if a.High(i) {
+			classes = append(classes, "pitch-high")
+			if i > 0 && !a.High(i-1) {
+				classes = append(classes, "pitch-rise")
+			}
+		} else {
+			classes = append(classes, "pitch-low")
+		}
+		if i == a.Position-1 {
+			classes = append(classes, "pitch-drop")
+		}
+		out.WriteString(`<span class="` + strings.Join(classes, " ") + `">` + html.EscapeString(mora) + `</span>`)
+	}
+	out.WriteString(`<span class="pitch-type">` + html.EscapeString(a.Pattern().String()) + `</span></span>`)
+	return out.String()
+}
+
+// Parse 以發音的假名解析重音標記；
+// 有多個重音時以 /、,、・ 或空白分隔 ("0/2")，或連續寫出圈號數字 ("⓪②")。
+// 也接受重音類型的名稱 ("平板"、"頭高型")，依拍數換算為下降位置。
+// 下降位置不能超過發音的拍數
+func Parse(notation, reading string) ([]Accent, error) {
+	moras, err := Moras(reading)
+	if err != nil {
+		return nil, err
+	}
+	if len(moras) == 0 {
+		return nil, fmt.Errorf("發音為空，無法判斷重音")
+	}
+
+	var positions []int
+	if pattern, ok := ParsePattern(notation); ok {
+		position, err := pattern.Position(len(moras))
+		if err != nil {
+			return nil, err
+		}
+		positions = []int{position}
+	} else if positions, err = ParsePositions(notation); err != nil {
+		return nil, err
+	}
+
+	accents := make([]Accent, len(positions))
+	for i, position := range positions {
+		if position > len(moras) {
+			return nil, fmt.Errorf("重音位置 %d 超過發音「%s」的拍數 (%d 拍)", position, reading, len(moras))
+		}
+		accents[i] = Accent{Position: position, Moras: moras}
+	}
+	return accents, nil
+}
+
+// ParsePositions 解析重音標記中的下降位置：
+// 支援半形與全形數字 ("1"、"１")、圈號數字 ("①"、"⓪") 與以 [] 括住的數字 ("[2]")
+func ParsePositions(notation string) ([]int, error) {
+	var positions []int
+	var digits strings.Builder
+	flush := func() error {
+		if digits.Len() == 0 {
+			return nil
+		}
+		n, err := strconv.Atoi(digits.String())
+		if err != nil {
+			return fmt.Errorf("無法解析重音標記: %s", notation)
+		}
+		positions = append(positions, n)
+		digits.Reset()
+		return nil
+	}
+
+	for _, r := range notation {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+			continue
+		case r >= '０' && r <= '９':
+			digits.WriteRune(r - '０' + '0')
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		switch {
+		case r == '⓪':
+			positions = append(positions, 0)
+		case r >= '①' && r <= '⑳':
+			positions = append(positions, int(r-'①')+1)
+		case strings.ContainsRune("/,、・[]［］ ", r) || unicode.IsSpace(r):
+		default:
+			return nil, fmt.Errorf("無法解析重音標記: %s (應為數字，例如 0、1 或 ②)", notation)
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(positions) == 0 {
+		return nil, fmt.Errorf("無法解析重音標記: %s (應為數字，例如 0、1 或 ②)", notation)
+	}
+	return positions, nil
+}
+
+// smallKana 與前一個假名合為一拍的小寫假名 (拗音)；促音っ與撥音ん自成一拍
+const smallKana = "ゃゅょぁぃぅぇぉゎャュョァィゥェォヮ"
+
+// Moras 將假名分為拍 (きょう → きょ/う)；長音符號ー、促音與撥音各為一拍，
+// 空白與・會被忽略，包含假名以外的字元時回傳錯誤
+func Moras(kana string) ([]string, error) {
+	var moras []string
+	for _, r := range kana {
+		switch {
+		case r == '・' || unicode.IsSpace(r):
+			continue
+		case strings.ContainsRune(smallKana, r):
+			if len(moras) == 0 {
+				return nil, fmt.Errorf("發音「%s」不能以小寫假名開頭", kana)
+			}
+			moras[len(moras)-1] += string(r)
+		case japanese.IsKana(r):
+			moras = append(moras, string(r))
+		default:
+			return nil, fmt.Errorf("發音「%s」包含假名以外的字元: %c", kana, r)
+		}
+	}
+	return moras, nil
+}
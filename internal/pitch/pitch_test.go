@@ -0,0 +1,169 @@
+package pitch
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMoras tests splitting kana into morae, including contracted sounds and long vowels
+func TestMoras(t *testing.T) {
+	tests := []struct {
+		kana     string
+		expected string
+		wantErr  bool
+	}{
+		{"のむ", "の/む", false},
+		{"きょう", "きょ/う", false},
+		{"がっこう", "が/っ/こ/う", false},
+		{"コーヒー", "コ/ー/ヒ/ー", false},
+		{"しんぶん", "し/ん/ぶ/ん", false},
+		{"飲む", "", true},
+		{"nomu", "", true},
+		{"ょう", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kana, func(t *testing.T) {
+			moras, err := Moras(tt.kana)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Moras() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := strings.Join(moras, "/"); got != tt.expected {
+				t.Errorf("Moras(%s) = %s, expected %s", tt.kana, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParsePositions tests the accepted accent notations
+func TestParsePositions(t *testing.T) {
+	tests := []struct {
+		notation string
+		expected []int
+		wantErr  bool
+	}{
+		{"0", []int{0}, false},
+		{"1", []int{1}, false},
+		{"②", []int{2}, false},
+		{"⓪", []int{0}, false},
+		{"３", []int{3}, false},
+		{"[2]", []int{2}, false},
+		{"0/2", []int{0, 2}, false},
+		{"⓪②", []int{0, 2}, false},
+		{"12", []int{12}, false},
+		{"", nil, true},
+		{"平板", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			positions, err := ParsePositions(tt.notation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePositions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(positions) != len(tt.expected) {
+				t.Fatalf("ParsePositions(%s) = %v, expected %v", tt.notation, positions, tt.expected)
+			}
+			for i := range positions {
+				if positions[i] != tt.expected[i] {
+					t.Errorf("ParsePositions(%s) = %v, expected %v", tt.notation, positions, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+// TestParse tests classification and high/low morae for each pattern
+func TestParse(t *testing.T) {
+	tests := []struct {
+		notation string
+		reading  string
+		pattern  Pattern
+		pitches  string // H/L per mora
+	}{
+		{"0", "さくら", Heiban, "LHH"},
+		{"1", "のむ", Atamadaka, "HL"},
+		{"②", "たべる", Nakadaka, "LHL"},
+		{"3", "おとうと", Nakadaka, "LHHL"},
+		{"2", "ねこ", Odaka, "LH"},
+		{"4", "うつくしい", Nakadaka, "LHHHL"},
+		{"0", "ひ", Heiban, "L"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reading, func(t *testing.T) {
+			accents, err := Parse(tt.notation, tt.reading)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			accent := accents[0]
+			if accent.Pattern() != tt.pattern {
+				t.Errorf("Pattern() = %v, expected %v", accent.Pattern(), tt.pattern)
+			}
+			var pitches strings.Builder
+			for i := range accent.Moras {
+				if accent.High(i) {
+					pitches.WriteString("H")
+				} else {
+					pitches.WriteString("L")
+				}
+			}
+			if pitches.String() != tt.pitches {
+				t.Errorf("pitches = %s, expected %s", pitches.String(), tt.pitches)
+			}
+		})
+	}
+
+	if _, err := Parse("4", "のむ"); err == nil {
+		t.Error("Parse() expected an error for a position beyond the mora count")
+	}
+}
+
+// TestParsePatternNames tests converting pattern names to drop positions by mora count
+func TestParsePatternNames(t *testing.T) {
+	tests := []struct {
+		notation string
+		reading  string
+		position int
+		wantErr  bool
+	}{
+		{"平板", "さくら", 0, false},
+		{"頭高型", "のむ", 1, false},
+		{"尾高", "おとうと", 4, false},
+		{"中高", "たべる", 2, false},
+		{" 中高型 ", "たべる", 2, false},
+		{"中高", "うつくしい", 0, true},
+		{"高い", "たかい", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notation+tt.reading, func(t *testing.T) {
+			accents, err := Parse(tt.notation, tt.reading)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && accents[0].Position != tt.position {
+				t.Errorf("Parse(%s, %s) position = %d, expected %d", tt.notation, tt.reading, accents[0].Position, tt.position)
+			}
+		})
+	}
+}
+
+// TestAccentHTML tests the overline and drop markers of the pitch graph
+func TestAccentHTML(t *testing.T) {
+	accents, err := Parse("2", "たべる")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	got := accents[0].HTML()
+	for _, want := range []string{
+		`<span class="pitch-mora pitch-low">た</span>`,
+		`<span class="pitch-mora pitch-high pitch-rise pitch-drop">べ</span>`,
+		`<span class="pitch-mora pitch-low">る</span>`,
+		`<span class="pitch-type">中高型</span>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML() = %s\nmissing %s", got, want)
+		}
+	}
+}
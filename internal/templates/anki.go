@@ -0,0 +1,159 @@
+package templates
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AnkiTemplate 轉換為 Anki 格式的卡片模板
+type AnkiTemplate struct {
+	Front string
+	Back  string
+	CSS   string
+}
+
+var (
+	// actionPattern 比對 Go 模板的動作 ({{...}})
+	actionPattern = regexp.MustCompile(`{{(.*?)}}`)
+	// stylePattern 比對 <style> 區塊
+	stylePattern = regexp.MustCompile(`(?s)<style[^>]*>(.*?)</style>`)
+	// bodyPattern 比對 <body> 的內容
+	bodyPattern = regexp.MustCompile(`(?s)<body[^>]*>(.*)</body>`)
+	// fieldPattern 比對欄位參照 (.欄位名稱)
+	fieldPattern = regexp.MustCompile(`^\.([^\s.{}]+)$`)
+	// filterActionPattern 比對套用 Anki 過濾器的欄位 (cloze/furigana/kanji/kana .欄位名稱)
+	filterActionPattern = regexp.MustCompile(`^(cloze|furigana|kanji|kana)\s+\.([^\s.{}]+)$`)
+	// pitchActionPattern 比對重音圖 (pitch .重音欄位 .發音欄位)
+	pitchActionPattern = regexp.MustCompile(`^pitch\s+\.([^\s.{}]+)\s+\.([^\s.{}]+)$`)
+)
+
+// GetAnkiTemplate 將嵌入的 HTML 模板轉換為 Anki 卡片模板與 CSS，
+// 讓 CardService 的預覽與 Anki 顯示的內容一致
+func (tm *TemplateManager) GetAnkiTemplate(cardType string) (*AnkiTemplate, error) {
+	if err := tm.ValidateTemplate(cardType); err != nil {
+		return nil, err
+	}
+	return tm.convertTemplate(cardType)
+}
+
+// convertTemplate 將 <name>_front.html 與 <name>_back.html 轉換為 Anki 卡片模板與 CSS
+func (tm *TemplateManager) convertTemplate(name string) (*AnkiTemplate, error) {
+	ankiTemplate := &AnkiTemplate{}
+	var styles []string
+
+	for _, side := range []string{"front", "back"} {
+		raw, err := tm.GetRawTemplate(name, side)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := ToMustache(extractBody(raw))
+		if err != nil {
+			return nil, fmt.Errorf("轉換 %s_%s.html 失敗: %w", name, side, err)
+		}
+		content = withPitchScript(content)
+
+		if side == "front" {
+			ankiTemplate.Front = content
+		} else {
+			ankiTemplate.Back = content
+		}
+		styles = append(styles, extractStyles(raw)...)
+	}
+
+	ankiTemplate.CSS = strings.Join(styles, "\n")
+	return ankiTemplate, nil
+}
+
+// ToMustache 將 Go html/template 語法轉換為 Anki 的 mustache 語法
+//
+//	{{.欄位}}                     → {{欄位}}
+//	{{if .欄位}}...{{end}}        → {{#欄位}}...{{/欄位}}
+//	{{if .欄位}}...{{else}}...{{end}} → {{#欄位}}...{{/欄位}}{{^欄位}}...{{/欄位}}
+//	{{cloze .欄位}}               → {{cloze:欄位}}
+//	{{furigana .欄位}}            → {{furigana:欄位}} (kanji、kana 相同)
+//	{{pitch .重音 .發音}}          → <span class="pitch" data-accent="{{重音}}" ...> (由 pitch.js 繪製)
+//
+// Anki 不支援的語法 (or、and、range、管線等) 會回傳錯誤
+func ToMustache(src string) (string, error) {
+	var out strings.Builder
+	var stack []string
+
+	last := 0
+	for _, loc := range actionPattern.FindAllStringSubmatchIndex(src, -1) {
+		out.WriteString(src[last:loc[0]])
+		last = loc[1]
+
+		action := strings.TrimSpace(src[loc[2]:loc[3]])
+		switch {
+		case fieldPattern.MatchString(action):
+			out.WriteString("{{" + action[1:] + "}}")
+
+		case filterActionPattern.MatchString(action):
+			match := filterActionPattern.FindStringSubmatch(action)
+			out.WriteString("{{" + match[1] + ":" + match[2] + "}}")
+
+		case pitchActionPattern.MatchString(action):
+			match := pitchActionPattern.FindStringSubmatch(action)
+			out.WriteString(pitchMustache(match[1], match[2]))
+
+		case strings.HasPrefix(action, "if "):
+			cond := strings.TrimSpace(strings.TrimPrefix(action, "if "))
+			if !fieldPattern.MatchString(cond) {
+				return "", fmt.Errorf("不支援的模板語法: {{%s}}", action)
+			}
+			stack = append(stack, cond[1:])
+			out.WriteString("{{#" + cond[1:] + "}}")
+
+		case action == "else":
+			if len(stack) == 0 {
+				return "", fmt.Errorf("多餘的 {{else}}")
+			}
+			field := stack[len(stack)-1]
+			out.WriteString("{{/" + field + "}}{{^" + field + "}}")
+
+		case action == "end":
+			if len(stack) == 0 {
+				return "", fmt.Errorf("多餘的 {{end}}")
+			}
+			field := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			out.WriteString("{{/" + field + "}}")
+
+		default:
+			return "", fmt.Errorf("不支援的模板語法: {{%s}}", action)
+		}
+	}
+	out.WriteString(src[last:])
+
+	if len(stack) > 0 {
+		return "", fmt.Errorf("缺少 {{end}}: {{if .%s}}", stack[len(stack)-1])
+	}
+	return out.String(), nil
+}
+
+// extractStyles 取出 HTML 文件中所有 <style> 區塊的內容
+func extractStyles(html string) []string {
+	var styles []string
+	for _, match := range stylePattern.FindAllStringSubmatch(html, -1) {
+		styles = append(styles, strings.TrimSpace(match[1]))
+	}
+	return styles
+}
+
+// extractBody 取出 HTML 文件 <body> 的內容；
+// Anki 本身會將卡片放在 class="card" 的 body 中，因此移除最外層的 .card 容器
+func extractBody(html string) string {
+	body := html
+	if match := bodyPattern.FindStringSubmatch(html); match != nil {
+		body = match[1]
+	}
+	body = strings.TrimSpace(body)
+
+	const wrapperStart, wrapperEnd = `<div class="card">`, `</div>`
+	if strings.HasPrefix(body, wrapperStart) && strings.HasSuffix(body, wrapperEnd) {
+		body = strings.TrimSpace(body[len(wrapperStart) : len(body)-len(wrapperEnd)])
+	}
+	return body
+}
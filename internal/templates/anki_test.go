@@ -0,0 +1,247 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToMustache(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "Field",
+			src:  `<div>{{.核心單字}}</div>`,
+			want: `<div>{{核心單字}}</div>`,
+		},
+		{
+			name: "Field with spaces",
+			src:  `{{ .發音 }}`,
+			want: `{{發音}}`,
+		},
+		{
+			name: "If block",
+			src:  `{{if .圖片提示}}<img src="{{.圖片提示}}">{{end}}`,
+			want: `{{#圖片提示}}<img src="{{圖片提示}}">{{/圖片提示}}`,
+		},
+		{
+			name: "If else block",
+			src:  `{{if .重音}}{{.重音}}{{else}}-{{end}}`,
+			want: `{{#重音}}{{重音}}{{/重音}}{{^重音}}-{{/重音}}`,
+		},
+		{
+			name: "Nested blocks",
+			src:  `{{if .同義詞}}{{if .反義詞}}both{{end}}{{end}}`,
+			want: `{{#同義詞}}{{#反義詞}}both{{/反義詞}}{{/同義詞}}`,
+		},
+		{
+			name: "Cloze field",
+			src:  `<div>{{cloze .克漏字}}</div>`,
+			want: `<div>{{cloze:克漏字}}</div>`,
+		},
+		{
+			name: "Furigana fields",
+			src:  `{{furigana .情境例句}}{{kanji .核心單字}}{{kana .核心單字}}`,
+			want: `{{furigana:情境例句}}{{kanji:核心單字}}{{kana:核心單字}}`,
+		},
+		{
+			name: "Pitch accent",
+			src:  `<div>{{pitch .重音 .發音}}</div>`,
+			want: `<div><span class="pitch" data-accent="{{重音}}" data-reading="{{發音}}">{{重音}}</span></div>`,
+		},
+		{
+			name:    "Unsupported or",
+			src:     `{{if or .同義詞 .反義詞}}x{{end}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Unsupported range",
+			src:     `{{range .items}}x{{end}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Missing end",
+			src:     `{{if .圖片提示}}x`,
+			wantErr: true,
+		},
+		{
+			name:    "Extra end",
+			src:     `x{{end}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToMustache(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToMustache() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ToMustache() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateManager_GetAnkiTemplate(t *testing.T) {
+	manager, err := NewTemplateManager()
+	if err != nil {
+		t.Fatalf("Failed to create template manager: %v", err)
+	}
+
+	for _, cardType := range []string{"verb", "adjective", "normal", "grammar", "kanji", "cloze"} {
+		t.Run(cardType, func(t *testing.T) {
+			ankiTemplate, err := manager.GetAnkiTemplate(cardType)
+			if err != nil {
+				t.Fatalf("GetAnkiTemplate(%s) returned error: %v", cardType, err)
+			}
+
+			for side, content := range map[string]string{"front": ankiTemplate.Front, "back": ankiTemplate.Back} {
+				if content == "" {
+					t.Errorf("%s template is empty", side)
+				}
+				for _, goSyntax := range []string{"{{.", "{{if", "{{end", "<html", "<style", `<div class="card">`} {
+					if strings.Contains(content, goSyntax) {
+						t.Errorf("%s template still contains %q", side, goSyntax)
+					}
+				}
+			}
+
+			if !strings.Contains(ankiTemplate.CSS, ".card {") || strings.Contains(ankiTemplate.CSS, "<style") {
+				t.Errorf("CSS was not extracted correctly: %q", ankiTemplate.CSS)
+			}
+		})
+	}
+
+	if _, err := manager.GetAnkiTemplate("invalid"); err == nil {
+		t.Error("GetAnkiTemplate(invalid) did not return error")
+	}
+}
+
+func TestTemplateManager_RenderCloze(t *testing.T) {
+	manager, err := NewTemplateManager()
+	if err != nil {
+		t.Fatalf("Failed to create template manager: %v", err)
+	}
+
+	data := map[string]interface{}{
+		"克漏字":  "毎朝コーヒーを{{c1::飲む::喝}}。",
+		"例句翻譯": "每天早上喝咖啡。",
+	}
+
+	front, err := manager.RenderCardFront("cloze", data)
+	if err != nil {
+		t.Fatalf("RenderCardFront() error = %v", err)
+	}
+	if !strings.Contains(front, `<span class="cloze">[喝]</span>`) || strings.Contains(front, "飲む") {
+		t.Errorf("RenderCardFront() should hide the answer and show the hint:\n%s", front)
+	}
+
+	back, err := manager.RenderCardBack("cloze", data)
+	if err != nil {
+		t.Fatalf("RenderCardBack() error = %v", err)
+	}
+	if !strings.Contains(back, `<span class="cloze">飲む</span>`) {
+		t.Errorf("RenderCardBack() should reveal the answer:\n%s", back)
+	}
+}
+
+func TestTemplateManager_RenderFurigana(t *testing.T) {
+	manager, err := NewTemplateManager()
+	if err != nil {
+		t.Fatalf("Failed to create template manager: %v", err)
+	}
+
+	data := map[string]interface{}{
+		"核心單字": "寝[ね]る",
+		"情境例句": "毎晩 十一時[じゅういちじ]に 寝[ね]る。",
+		"核心意義": "睡覺",
+	}
+
+	front, err := manager.RenderCardFront("verb", data)
+	if err != nil {
+		t.Fatalf("RenderCardFront() error = %v", err)
+	}
+	if !strings.Contains(front, "毎晩十一時に寝る。") || strings.Contains(front, "[ね]") {
+		t.Errorf("RenderCardFront() should show the sentence without readings:\n%s", front)
+	}
+
+	back, err := manager.RenderCardBack("verb", data)
+	if err != nil {
+		t.Fatalf("RenderCardBack() error = %v", err)
+	}
+	if !strings.Contains(back, "<ruby><rb>寝</rb><rt>ね</rt></ruby>る") {
+		t.Errorf("RenderCardBack() should render the readings as <ruby>:\n%s", back)
+	}
+}
+
+func TestTemplateManager_RenderPitch(t *testing.T) {
+	manager, err := NewTemplateManager()
+	if err != nil {
+		t.Fatalf("Failed to create template manager: %v", err)
+	}
+
+	data := map[string]interface{}{"核心單字": "食べる", "發音": "たべる", "重音": "②"}
+	back, err := manager.RenderCardBack("verb", data)
+	if err != nil {
+		t.Fatalf("RenderCardBack() error = %v", err)
+	}
+	if !strings.Contains(back, `<span class="pitch-mora pitch-high pitch-rise pitch-drop">べ</span>`) {
+		t.Errorf("RenderCardBack() should render the pitch graph:\n%s", back)
+	}
+
+	data["重音"] = "高く始まる"
+	back, err = manager.RenderCardBack("verb", data)
+	if err != nil {
+		t.Fatalf("RenderCardBack() error = %v", err)
+	}
+	if !strings.Contains(back, `<span class="pitch">高く始まる</span>`) {
+		t.Errorf("RenderCardBack() should fall back to the notation:\n%s", back)
+	}
+
+	ankiTemplate, err := manager.GetAnkiTemplate("verb")
+	if err != nil {
+		t.Fatalf("GetAnkiTemplate() error = %v", err)
+	}
+	if !strings.Contains(ankiTemplate.Back, "<script>") || strings.Contains(ankiTemplate.Front, "<script>") {
+		t.Errorf("GetAnkiTemplate() should add the pitch script to the back only")
+	}
+	if !strings.Contains(ankiTemplate.CSS, ".pitch-drop") {
+		t.Errorf("GetAnkiTemplate() CSS is missing the pitch graph styles")
+	}
+}
+
+func TestTemplateManager_GetDirectionTemplate(t *testing.T) {
+	manager, err := NewTemplateManager()
+	if err != nil {
+		t.Fatalf("Failed to create template manager: %v", err)
+	}
+
+	for _, direction := range Directions() {
+		ankiTemplate, err := manager.GetDirectionTemplate(direction)
+		if err != nil {
+			t.Fatalf("GetDirectionTemplate(%s) returned error: %v", direction, err)
+		}
+		if strings.Contains(ankiTemplate.Front+ankiTemplate.Back, "{{.") {
+			t.Errorf("GetDirectionTemplate(%s) left Go template syntax", direction)
+		}
+		if DirectionTemplateName(direction) == "" {
+			t.Errorf("DirectionTemplateName(%s) is empty", direction)
+		}
+	}
+
+	// The listening front is empty without audio, so Anki skips the card
+	listening, _ := manager.GetDirectionTemplate(DirectionListening)
+	front := strings.TrimSpace(listening.Front)
+	if !strings.HasPrefix(front, "{{#音檔}}") || !strings.HasSuffix(front, "{{/音檔}}") {
+		t.Errorf("listening front should be wrapped in {{#音檔}}: %q", front)
+	}
+
+	if _, err := manager.GetDirectionTemplate("writing"); err == nil {
+		t.Error("GetDirectionTemplate(writing) expected an error")
+	}
+}
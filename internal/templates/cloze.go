@@ -0,0 +1,43 @@
+package templates
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// clozeDeletionPattern 比對克漏字語法 {{c1::答案}} 或 {{c1::答案::提示}}
+var clozeDeletionPattern = regexp.MustCompile(`{{c\d+::(.*?)(?:::(.*?))?}}`)
+
+// clozeFuncs 回傳預覽用的模板函式；Anki 模板中 {{cloze .欄位}} 會轉換為 {{cloze:欄位}}
+func clozeFuncs(side string) template.FuncMap {
+	hidden := side == "front"
+	return template.FuncMap{
+		"cloze": func(text string) template.HTML {
+			return renderCloze(text, hidden)
+		},
+	}
+}
+
+// renderCloze 模擬 Anki 顯示克漏字的方式：正面以 [...] 或 [提示] 取代答案，背面標示答案
+func renderCloze(text string, hidden bool) template.HTML {
+	var out strings.Builder
+	last := 0
+	for _, loc := range clozeDeletionPattern.FindAllStringSubmatchIndex(text, -1) {
+		out.WriteString(html.EscapeString(text[last:loc[0]]))
+		last = loc[1]
+
+		answer := text[loc[2]:loc[3]]
+		switch {
+		case !hidden:
+			out.WriteString(`<span class="cloze">` + html.EscapeString(answer) + `</span>`)
+		case loc[4] >= 0:
+			out.WriteString(`<span class="cloze">[` + html.EscapeString(text[loc[4]:loc[5]]) + `]</span>`)
+		default:
+			out.WriteString(`<span class="cloze">[...]</span>`)
+		}
+	}
+	out.WriteString(html.EscapeString(text[last:]))
+	return template.HTML(out.String())
+}
@@ -0,0 +1,47 @@
+package templates
+
+import "fmt"
+
+// 卡片方向：同一筆記額外產生的卡片 (需要核心單字、核心意義、發音等單字欄位)
+const (
+	// DirectionProduction 中→日：看中文意思回想日文
+	DirectionProduction = "production"
+	// DirectionReading 讀音：看漢字回想發音
+	DirectionReading = "reading"
+	// DirectionListening 聽力：只播放音檔 (音檔欄位為空時 Anki 不會產生這張卡片)
+	DirectionListening = "listening"
+)
+
+// directionNames 各方向在 Anki 中的卡片模板名稱
+var directionNames = map[string]string{
+	DirectionProduction: "中→日",
+	DirectionReading:    "讀音",
+	DirectionListening:  "聽力",
+}
+
+// Directions 回傳所有支援的卡片方向
+func Directions() []string {
+	return []string{DirectionProduction, DirectionReading, DirectionListening}
+}
+
+// DirectionTemplateName 回傳方向在 Anki 中的卡片模板名稱
+func DirectionTemplateName(direction string) string {
+	return directionNames[direction]
+}
+
+// ValidateDirection 檢查卡片方向是否支援
+func ValidateDirection(direction string) error {
+	if _, ok := directionNames[direction]; !ok {
+		return fmt.Errorf("不支援的卡片方向: %s (可用: production, reading, listening)", direction)
+	}
+	return nil
+}
+
+// GetDirectionTemplate 將方向的 HTML 模板 (<方向>_front.html、<方向>_back.html) 轉換為 Anki 卡片模板；
+// CSS 只包含方向專用的樣式，其餘沿用卡片類型本身的樣式
+func (tm *TemplateManager) GetDirectionTemplate(direction string) (*AnkiTemplate, error) {
+	if err := ValidateDirection(direction); err != nil {
+		return nil, err
+	}
+	return tm.convertTemplate(direction)
+}
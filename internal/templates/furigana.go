@@ -0,0 +1,47 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+
+	"anki-japanese-cli/internal/furigana"
+)
+
+// furiganaFuncs 回傳預覽用的振り仮名模板函式，對應 Anki 的同名過濾器：
+// {{furigana .欄位}} 以 <ruby> 顯示讀音，{{kanji .欄位}} 只顯示漢字，{{kana .欄位}} 只顯示讀音
+func furiganaFuncs() template.FuncMap {
+	return template.FuncMap{
+		"furigana": func(value interface{}) template.HTML {
+			return template.HTML(furigana.Ruby(fieldText(value)))
+		},
+		"kanji": func(value interface{}) template.HTML {
+			return template.HTML(html.EscapeString(furigana.Strip(fieldText(value))))
+		},
+		"kana": func(value interface{}) template.HTML {
+			return template.HTML(html.EscapeString(furigana.Kana(fieldText(value))))
+		},
+	}
+}
+
+// templateFuncs 回傳模板的所有函式 (克漏字、振り仮名與重音)
+func templateFuncs(side string) template.FuncMap {
+	funcs := clozeFuncs(side)
+	for _, extra := range []template.FuncMap{furiganaFuncs(), pitchFuncs()} {
+		for name, fn := range extra {
+			funcs[name] = fn
+		}
+	}
+	return funcs
+}
+
+// fieldText 將欄位值轉換為文字 (欄位不存在時為空字串)
+func fieldText(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
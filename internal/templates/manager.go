@@ -44,6 +44,8 @@ func (tm *TemplateManager) loadTemplates() error {
 		"adjective_front.html", "adjective_back.html",
 		"normal_front.html", "normal_back.html",
 		"grammar_front.html", "grammar_back.html",
+		"kanji_front.html", "kanji_back.html",
+		"cloze_front.html", "cloze_back.html",
 	}
 
 	// 按卡片類型分組
@@ -60,7 +62,7 @@ func (tm *TemplateManager) loadTemplates() error {
 			cardTypes[cardType] = &CardTemplate{}
 		}
 
-		tmpl, err := template.New(file).Parse(string(content))
+		tmpl, err := template.New(file).Funcs(templateFuncs(side)).Parse(string(content))
 		if err != nil {
 			return fmt.Errorf("解析模板 %s 失敗: %w", file, err)
 		}
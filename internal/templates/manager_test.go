@@ -25,7 +25,7 @@ func TestNewTemplateManager(t *testing.T) {
 	}
 	
 	// Check if all expected templates are loaded
-	expectedTemplates := []string{"verb", "adjective", "normal", "grammar"}
+	expectedTemplates := []string{"verb", "adjective", "normal", "grammar", "kanji", "cloze"}
 	for _, templateName := range expectedTemplates {
 		template, exists := manager.templates[templateName]
 		if !exists {
@@ -322,7 +322,7 @@ func TestTemplateManager_GetAvailableTemplates(t *testing.T) {
 	}
 	
 	// Check if all expected templates are included
-	expectedTemplates := []string{"verb", "adjective", "normal", "grammar"}
+	expectedTemplates := []string{"verb", "adjective", "normal", "grammar", "kanji", "cloze"}
 	for _, expected := range expectedTemplates {
 		found := false
 		for _, actual := range templates {
@@ -0,0 +1,54 @@
+package templates
+
+import (
+	_ "embed"
+	"html"
+	"html/template"
+	"strings"
+
+	"anki-japanese-cli/internal/pitch"
+)
+
+// pitchScript 在 Anki 中將重音標記繪製為音高圖的腳本 (Anki 的模板無法執行 Go 程式)；
+// 修改 pitchFuncs 或腳本時須同時更新另一方，testdata/pitch.json 確保兩者的輸出相同
+//
+//go:embed pitch.js
+var pitchScript string
+
+// pitchClass 重音圖容器的 class；轉換為 Anki 模板時，使用此 class 的卡片會加上 pitchScript
+const pitchClass = `class="pitch"`
+
+// pitchFuncs 回傳預覽用的重音模板函式：
+// {{pitch .重音 .發音}} 將重音標記依發音繪製為音高圖，無法解析時顯示原本的標記
+func pitchFuncs() template.FuncMap {
+	return template.FuncMap{
+		"pitch": func(accent, reading interface{}) template.HTML {
+			notation := fieldText(accent)
+			accents, err := pitch.Parse(notation, fieldText(reading))
+			if err != nil {
+				return template.HTML(`<span ` + pitchClass + `>` + html.EscapeString(notation) + `</span>`)
+			}
+			var out strings.Builder
+			out.WriteString(`<span ` + pitchClass + `>`)
+			for _, a := range accents {
+				out.WriteString(a.HTML())
+			}
+			out.WriteString(`</span>`)
+			return template.HTML(out.String())
+		},
+	}
+}
+
+// pitchMustache 將 {{pitch .重音 .發音}} 轉換為 Anki 模板：
+// 以 data 屬性保留重音與發音，由 pitchScript 在 Anki 中繪製
+func pitchMustache(accentField, readingField string) string {
+	return `<span ` + pitchClass + ` data-accent="{{` + accentField + `}}" data-reading="{{` + readingField + `}}">{{` + accentField + `}}</span>`
+}
+
+// withPitchScript 卡片使用重音圖時在模板最後加上 pitchScript
+func withPitchScript(content string) string {
+	if !strings.Contains(content, pitchClass) {
+		return content
+	}
+	return content + "\n<script>\n" + pitchScript + "</script>"
+}
@@ -0,0 +1,89 @@
+// 將 class="pitch" 元素的重音標記 (data-accent) 依發音 (data-reading) 繪製為音高圖，
+// 與預覽時 internal/pitch 產生的 HTML 相同 (testdata/pitch.json 為兩者共用的測試資料)；無法解析時保留原本的文字
+(function () {
+    var SMALL = "ゃゅょぁぃぅぇぉゎャュョァィゥェォヮ";
+    var TYPES = ["平板型", "頭高型", "中高型", "尾高型"];
+
+    function moras(kana) {
+        var result = [];
+        for (var i = 0; i < kana.length; i++) {
+            var c = kana.charAt(i);
+            if (c === "・" || /\s/.test(c)) continue;
+            if (SMALL.indexOf(c) >= 0) {
+                if (!result.length) return null;
+                result[result.length - 1] += c;
+            } else if (/[ぁ-ゟ゠-ヿㇰ-ㇿｦ-ﾝ]/.test(c)) {
+                result.push(c);
+            } else {
+                return null;
+            }
+        }
+        return result;
+    }
+
+    // 重音類型的名稱依拍數換算為下降位置 (中高型只有三拍時能確定)
+    function named(notation, count) {
+        var name = notation.replace(/^\s+|\s+$/g, "").replace(/型$/, "");
+        if (name === "平板") return [0];
+        if (name === "頭高") return [1];
+        if (name === "尾高") return [count];
+        if (name === "中高") return count === 3 ? [2] : null;
+        return undefined;
+    }
+
+    function positions(notation, count) {
+        var name = named(notation, count);
+        if (name !== undefined) return name;
+        var result = [];
+        var digits = "";
+        for (var i = 0; i < notation.length; i++) {
+            var code = notation.charCodeAt(i);
+            if (code >= 0x30 && code <= 0x39) { digits += notation.charAt(i); continue; }
+            if (code >= 0xff10 && code <= 0xff19) { digits += String.fromCharCode(code - 0xff10 + 0x30); continue; }
+            if (digits) { result.push(parseInt(digits, 10)); digits = ""; }
+            if (code === 0x24ea) result.push(0);
+            else if (code >= 0x2460 && code <= 0x2473) result.push(code - 0x2460 + 1);
+            else if ("/,、・[]［］ ".indexOf(notation.charAt(i)) < 0 && !/\s/.test(notation.charAt(i))) return null;
+        }
+        if (digits) result.push(parseInt(digits, 10));
+        return result.length ? result : null;
+    }
+
+    function high(position, i) {
+        if (position === 1) return i === 0;
+        if (i === 0) return false;
+        return position === 0 || i < position;
+    }
+
+    function graph(position, morae) {
+        var type = position === 0 ? 0 : position === 1 ? 1 : position === morae.length ? 3 : 2;
+        var html = '<span class="pitch-graph" title="' + TYPES[type] + ' [' + position + ']">';
+        for (var i = 0; i < morae.length; i++) {
+            var classes = "pitch-mora";
+            if (high(position, i)) {
+                classes += " pitch-high";
+                if (i > 0 && !high(position, i - 1)) classes += " pitch-rise";
+            } else {
+                classes += " pitch-low";
+            }
+            if (i === position - 1) classes += " pitch-drop";
+            html += '<span class="' + classes + '">' + morae[i] + '</span>';
+        }
+        return html + '<span class="pitch-type">' + TYPES[type] + '</span></span>';
+    }
+
+    var elements = document.querySelectorAll(".pitch[data-accent]");
+    for (var i = 0; i < elements.length; i++) {
+        var element = elements[i];
+        var morae = moras(element.getAttribute("data-reading") || "");
+        if (!morae || !morae.length) continue;
+        var accents = positions(element.getAttribute("data-accent") || "", morae.length);
+        if (!accents) continue;
+        var html = "";
+        for (var j = 0; j < accents.length; j++) {
+            if (accents[j] > morae.length) { html = ""; break; }
+            html += graph(accents[j], morae);
+        }
+        if (html) element.innerHTML = html;
+    }
+})();
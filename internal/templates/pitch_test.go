@@ -0,0 +1,96 @@
+package templates
+
+import (
+	"encoding/json"
+	"html/template"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// pitchFixture is one case of testdata/pitch.json, shared by the Go preview renderer and pitch.js
+type pitchFixture struct {
+	Accent  string `json:"accent"`
+	Reading string `json:"reading"`
+	HTML    string `json:"html"` // content of the class="pitch" element after rendering
+}
+
+func loadPitchFixtures(t *testing.T) []pitchFixture {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("testdata", "pitch.json"))
+	if err != nil {
+		t.Fatalf("Failed to read pitch fixtures: %v", err)
+	}
+	var fixtures []pitchFixture
+	if err := json.Unmarshal(content, &fixtures); err != nil {
+		t.Fatalf("Failed to parse pitch fixtures: %v", err)
+	}
+	return fixtures
+}
+
+// TestPitchFixtures_Go checks the preview renderer ({{pitch .重音 .發音}}) against the shared fixtures
+func TestPitchFixtures_Go(t *testing.T) {
+	render := pitchFuncs()["pitch"].(func(interface{}, interface{}) template.HTML)
+	for _, fixture := range loadPitchFixtures(t) {
+		want := `<span ` + pitchClass + `>` + fixture.HTML + `</span>`
+		if got := string(render(fixture.Accent, fixture.Reading)); got != want {
+			t.Errorf("pitch(%q, %q) = %s\nexpected %s", fixture.Accent, fixture.Reading, got, want)
+		}
+	}
+}
+
+// pitchHarness runs pitch.js against the fixtures with a minimal document stub and prints the resulting innerHTML
+const pitchHarness = `
+var fixtures = JSON.parse(require("fs").readFileSync(process.argv[2], "utf8"));
+var elements = fixtures.map(function (f) {
+    return {
+        innerHTML: f.accent,
+        getAttribute: function (name) { return name === "data-accent" ? f.accent : f.reading; }
+    };
+});
+global.document = { querySelectorAll: function () { return elements; } };
+require(process.argv[3]);
+console.log(JSON.stringify(elements.map(function (e) { return e.innerHTML; })));
+`
+
+// TestPitchFixtures_JS checks the script used in Anki (pitch.js) against the same fixtures;
+// skipped when node is not installed
+func TestPitchFixtures_JS(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+	fixtures := loadPitchFixtures(t)
+
+	dir := t.TempDir()
+	harness := filepath.Join(dir, "harness.js")
+	script := filepath.Join(dir, "pitch.js")
+	if err := os.WriteFile(harness, []byte(pitchHarness), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, []byte(pitchScript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fixturePath, err := filepath.Abs(filepath.Join("testdata", "pitch.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output, err := exec.Command(node, harness, fixturePath, script).Output()
+	if err != nil {
+		t.Fatalf("node failed: %v", err)
+	}
+	var rendered []string
+	if err := json.Unmarshal(output, &rendered); err != nil {
+		t.Fatalf("Failed to parse node output %q: %v", output, err)
+	}
+	if len(rendered) != len(fixtures) {
+		t.Fatalf("pitch.js rendered %d elements, expected %d", len(rendered), len(fixtures))
+	}
+	for i, fixture := range fixtures {
+		if rendered[i] != fixture.HTML {
+			t.Errorf("pitch.js(%q, %q) = %s\nexpected %s", fixture.Accent, fixture.Reading, rendered[i], fixture.HTML)
+		}
+	}
+}